@@ -0,0 +1,101 @@
+package finance
+
+import "time"
+
+// Analysis holds the analyst coverage of a company.
+// Sections that were not requested are empty.
+type Analysis struct {
+	Symbol              string
+	EarningsHistory     []*EarningsReport
+	EarningsTrend       []*EarningsTrend
+	RecommendationTrend []*Recommendation
+	UpgradesDowngrades  []*RatingChange
+	PriceTarget         *PriceTarget
+}
+
+// EarningsReport is the earnings per share reported
+// for a quarter, against their consensus estimate.
+type EarningsReport struct {
+	// Quarter is the last day of the quarter.
+	Quarter time.Time
+	// Period is relative to the current quarter, such as -1q.
+	Period          string
+	EPSActual       Value
+	EPSEstimate     Value
+	EPSDifference   Value
+	SurprisePercent Value
+	Currency        string
+}
+
+// EarningsTrend is the consensus estimates for a period.
+type EarningsTrend struct {
+	// Period is relative to the current one, such as 0q,
+	// +1q, 0y or +1y.
+	Period string
+	// EndDate is the last day of the period.
+	EndDate  time.Time
+	Growth   Value
+	Earnings Estimate
+	Revenue  Estimate
+	// EPSTrend is the earnings estimate over the last 90 days.
+	EPSTrend struct {
+		Current       Value
+		SevenDaysAgo  Value
+		ThirtyDaysAgo Value
+		SixtyDaysAgo  Value
+		NinetyDaysAgo Value
+	}
+	// EPSRevisions counts the revisions of the earnings estimate.
+	EPSRevisions struct {
+		UpLast7Days    Value
+		UpLast30Days   Value
+		DownLast7Days  Value
+		DownLast30Days Value
+	}
+}
+
+// Estimate is a consensus estimate of earnings
+// per share or of revenue.
+type Estimate struct {
+	Avg      Value
+	Low      Value
+	High     Value
+	YearAgo  Value
+	Analysts Value
+	Growth   Value
+	Currency string
+}
+
+// Recommendation counts the analyst
+// recommendations of a month.
+type Recommendation struct {
+	// Period is relative to the current month, such as 0m or -1m.
+	Period     string `json:"period"`
+	StrongBuy  int    `json:"strongBuy"`
+	Buy        int    `json:"buy"`
+	Hold       int    `json:"hold"`
+	Sell       int    `json:"sell"`
+	StrongSell int    `json:"strongSell"`
+}
+
+// RatingChange is a change of rating by an analyst firm.
+type RatingChange struct {
+	Date      time.Time
+	Firm      string
+	FromGrade string
+	ToGrade   string
+	// Action is up, down, main (maintained), init or reit.
+	Action string
+}
+
+// PriceTarget is the consensus of analyst price targets.
+type PriceTarget struct {
+	Current            Value
+	High               Value
+	Low                Value
+	Mean               Value
+	Median             Value
+	Analysts           Value
+	RecommendationMean Value
+	RecommendationKey  string
+}
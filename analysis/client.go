@@ -0,0 +1,320 @@
+// Package analysis fetches the analyst coverage of a company:
+// earnings against estimates, consensus estimates,
+// recommendations, rating changes and price targets.
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/summary"
+)
+
+// Modules are the quoteSummary modules
+// an analysis is built from.
+var Modules = []summary.Module{
+	summary.EarningsHistory,
+	summary.EarningsTrend,
+	summary.RecommendationTrend,
+	summary.UpgradeDowngradeHistory,
+	summary.FinancialData,
+}
+
+// Client is used to invoke analysis APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and analysis information.
+type Params struct {
+	finance.Params `form:"-"`
+	// Symbol is the company analyzed.
+	Symbol string `form:"-"`
+	// Modules, if set, restricts the analysis
+	// to a subset of Modules.
+	Modules []summary.Module `form:"-"`
+}
+
+// Get returns the full analysis of a symbol.
+func Get(symbol string) (*finance.Analysis, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns the analysis requested by params.
+func GetP(params *Params) (*finance.Analysis, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns the analysis requested by params.
+func (c Client) GetP(params *Params) (*finance.Analysis, error) {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	modules := params.Modules
+	if len(modules) == 0 {
+		modules = Modules
+	}
+	sp := &summary.Params{Params: params.Params, Symbol: params.Symbol, Modules: modules}
+
+	r := result{}
+	if err := (summary.Client{B: c.B}).Decode(sp, &r); err != nil {
+		return nil, err
+	}
+	return r.analysis(params.Symbol), nil
+}
+
+// EarningsHistory returns the earnings reported by
+// a company in recent quarters, oldest first.
+func EarningsHistory(symbol string) ([]*finance.EarningsReport, error) {
+	return getC().EarningsHistory(symbol)
+}
+
+// EarningsHistory returns the earnings reported by
+// a company in recent quarters, oldest first.
+func (c Client) EarningsHistory(symbol string) ([]*finance.EarningsReport, error) {
+	a, err := c.GetP(&Params{Symbol: symbol, Modules: []summary.Module{summary.EarningsHistory}})
+	if err != nil {
+		return nil, err
+	}
+	return a.EarningsHistory, nil
+}
+
+// Recommendations returns the analyst recommendations
+// of a company by month, latest first.
+func Recommendations(symbol string) ([]*finance.Recommendation, error) {
+	return getC().Recommendations(symbol)
+}
+
+// Recommendations returns the analyst recommendations
+// of a company by month, latest first.
+func (c Client) Recommendations(symbol string) ([]*finance.Recommendation, error) {
+	a, err := c.GetP(&Params{Symbol: symbol, Modules: []summary.Module{summary.RecommendationTrend}})
+	if err != nil {
+		return nil, err
+	}
+	return a.RecommendationTrend, nil
+}
+
+// UpgradesDowngrades returns the rating changes
+// of a company, latest first.
+func UpgradesDowngrades(symbol string) ([]*finance.RatingChange, error) {
+	return getC().UpgradesDowngrades(symbol)
+}
+
+// UpgradesDowngrades returns the rating changes
+// of a company, latest first.
+func (c Client) UpgradesDowngrades(symbol string) ([]*finance.RatingChange, error) {
+	a, err := c.GetP(&Params{Symbol: symbol, Modules: []summary.Module{summary.UpgradeDowngradeHistory}})
+	if err != nil {
+		return nil, err
+	}
+	return a.UpgradesDowngrades, nil
+}
+
+// PriceTarget returns the analyst price target of a company.
+func PriceTarget(symbol string) (*finance.PriceTarget, error) {
+	return getC().PriceTarget(symbol)
+}
+
+// PriceTarget returns the analyst price target of a company.
+func (c Client) PriceTarget(symbol string) (*finance.PriceTarget, error) {
+	a, err := c.GetP(&Params{Symbol: symbol, Modules: []summary.Module{summary.FinancialData}})
+	if err != nil {
+		return nil, err
+	}
+	if a.PriceTarget == nil {
+		return nil, finance.CreateNotFoundError(symbol)
+	}
+	return a.PriceTarget, nil
+}
+
+// result is the quoteSummary result of an analysis.
+type result struct {
+	EarningsHistory *struct {
+		History []*struct {
+			Quarter         finance.Date  `json:"quarter"`
+			Period          string        `json:"period"`
+			EPSActual       finance.Value `json:"epsActual"`
+			EPSEstimate     finance.Value `json:"epsEstimate"`
+			EPSDifference   finance.Value `json:"epsDifference"`
+			SurprisePercent finance.Value `json:"surprisePercent"`
+			Currency        string        `json:"currency"`
+		} `json:"history"`
+	} `json:"earningsHistory"`
+	EarningsTrend *struct {
+		Trend []*struct {
+			Period   string        `json:"period"`
+			EndDate  string        `json:"endDate"`
+			Growth   finance.Value `json:"growth"`
+			Earnings struct {
+				estimate
+				YearAgo  finance.Value `json:"yearAgoEps"`
+				Currency string        `json:"earningsCurrency"`
+			} `json:"earningsEstimate"`
+			Revenue struct {
+				estimate
+				YearAgo  finance.Value `json:"yearAgoRevenue"`
+				Currency string        `json:"revenueCurrency"`
+			} `json:"revenueEstimate"`
+			EPSTrend struct {
+				Current       finance.Value `json:"current"`
+				SevenDaysAgo  finance.Value `json:"7daysAgo"`
+				ThirtyDaysAgo finance.Value `json:"30daysAgo"`
+				SixtyDaysAgo  finance.Value `json:"60daysAgo"`
+				NinetyDaysAgo finance.Value `json:"90daysAgo"`
+			} `json:"epsTrend"`
+			EPSRevisions struct {
+				UpLast7Days    finance.Value `json:"upLast7days"`
+				UpLast30Days   finance.Value `json:"upLast30days"`
+				DownLast7Days  finance.Value `json:"downLast7Days"`
+				DownLast30Days finance.Value `json:"downLast30days"`
+			} `json:"epsRevisions"`
+		} `json:"trend"`
+	} `json:"earningsTrend"`
+	RecommendationTrend *struct {
+		Trend []*finance.Recommendation `json:"trend"`
+	} `json:"recommendationTrend"`
+	UpgradeDowngradeHistory *struct {
+		History []*struct {
+			Date      finance.Date `json:"epochGradeDate"`
+			Firm      string       `json:"firm"`
+			FromGrade string       `json:"fromGrade"`
+			ToGrade   string       `json:"toGrade"`
+			Action    string       `json:"action"`
+		} `json:"history"`
+	} `json:"upgradeDowngradeHistory"`
+	FinancialData *finance.FinancialData `json:"financialData"`
+}
+
+// estimate holds the fields shared by
+// earnings and revenue estimates.
+type estimate struct {
+	Avg      finance.Value `json:"avg"`
+	Low      finance.Value `json:"low"`
+	High     finance.Value `json:"high"`
+	Analysts finance.Value `json:"numberOfAnalysts"`
+	Growth   finance.Value `json:"growth"`
+}
+
+// analysis converts r into an analysis of symbol.
+func (r *result) analysis(symbol string) *finance.Analysis {
+	a := &finance.Analysis{Symbol: symbol}
+
+	if r.EarningsHistory != nil {
+		for _, h := range r.EarningsHistory.History {
+			if h == nil {
+				continue
+			}
+			a.EarningsHistory = append(a.EarningsHistory, &finance.EarningsReport{
+				Quarter:         h.Quarter.Time,
+				Period:          h.Period,
+				EPSActual:       h.EPSActual,
+				EPSEstimate:     h.EPSEstimate,
+				EPSDifference:   h.EPSDifference,
+				SurprisePercent: h.SurprisePercent,
+				Currency:        h.Currency,
+			})
+		}
+		sort.SliceStable(a.EarningsHistory, func(i, j int) bool {
+			return a.EarningsHistory[i].Quarter.Before(a.EarningsHistory[j].Quarter)
+		})
+	}
+
+	if r.EarningsTrend != nil {
+		for _, t := range r.EarningsTrend.Trend {
+			if t == nil {
+				continue
+			}
+			e := &finance.EarningsTrend{Period: t.Period, Growth: t.Growth}
+			e.EndDate, _ = time.Parse("2006-01-02", t.EndDate)
+			e.Earnings = t.Earnings.estimate.convert(t.Earnings.YearAgo, t.Earnings.Currency)
+			e.Revenue = t.Revenue.estimate.convert(t.Revenue.YearAgo, t.Revenue.Currency)
+			e.EPSTrend.Current = t.EPSTrend.Current
+			e.EPSTrend.SevenDaysAgo = t.EPSTrend.SevenDaysAgo
+			e.EPSTrend.ThirtyDaysAgo = t.EPSTrend.ThirtyDaysAgo
+			e.EPSTrend.SixtyDaysAgo = t.EPSTrend.SixtyDaysAgo
+			e.EPSTrend.NinetyDaysAgo = t.EPSTrend.NinetyDaysAgo
+			e.EPSRevisions.UpLast7Days = t.EPSRevisions.UpLast7Days
+			e.EPSRevisions.UpLast30Days = t.EPSRevisions.UpLast30Days
+			e.EPSRevisions.DownLast7Days = t.EPSRevisions.DownLast7Days
+			e.EPSRevisions.DownLast30Days = t.EPSRevisions.DownLast30Days
+			a.EarningsTrend = append(a.EarningsTrend, e)
+		}
+	}
+
+	if r.RecommendationTrend != nil {
+		for _, t := range r.RecommendationTrend.Trend {
+			if t != nil {
+				a.RecommendationTrend = append(a.RecommendationTrend, t)
+			}
+		}
+		sort.SliceStable(a.RecommendationTrend, func(i, j int) bool {
+			return months(a.RecommendationTrend[i].Period) > months(a.RecommendationTrend[j].Period)
+		})
+	}
+
+	if r.UpgradeDowngradeHistory != nil {
+		for _, h := range r.UpgradeDowngradeHistory.History {
+			if h == nil {
+				continue
+			}
+			a.UpgradesDowngrades = append(a.UpgradesDowngrades, &finance.RatingChange{
+				Date:      h.Date.Time,
+				Firm:      h.Firm,
+				FromGrade: h.FromGrade,
+				ToGrade:   h.ToGrade,
+				Action:    h.Action,
+			})
+		}
+		sort.SliceStable(a.UpgradesDowngrades, func(i, j int) bool {
+			return a.UpgradesDowngrades[i].Date.After(a.UpgradesDowngrades[j].Date)
+		})
+	}
+
+	if f := r.FinancialData; f != nil {
+		a.PriceTarget = &finance.PriceTarget{
+			Current:            f.CurrentPrice,
+			High:               f.TargetHighPrice,
+			Low:                f.TargetLowPrice,
+			Mean:               f.TargetMeanPrice,
+			Median:             f.TargetMedianPrice,
+			Analysts:           f.NumberOfAnalystOpinions,
+			RecommendationMean: f.RecommendationMean,
+			RecommendationKey:  f.RecommendationKey,
+		}
+	}
+	return a
+}
+
+// months returns the month offset of a recommendation
+// period such as -1m, sorting unknown periods last.
+func months(period string) int {
+	n, err := strconv.Atoi(strings.TrimSuffix(period, "m"))
+	if err != nil {
+		return math.MinInt
+	}
+	return n
+}
+
+// convert returns e as an estimate.
+func (e estimate) convert(yearAgo finance.Value, currency string) finance.Estimate {
+	return finance.Estimate{
+		Avg:      e.Avg,
+		Low:      e.Low,
+		High:     e.High,
+		YearAgo:  yearAgo,
+		Analysts: e.Analysts,
+		Growth:   e.Growth,
+		Currency: currency,
+	}
+}
@@ -0,0 +1,163 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/summary"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("analysis modules are only served by the in-process server")
+	}
+}
+
+func TestGetAnalysis(t *testing.T) {
+	skipMock(t)
+
+	a, err := Get(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, tests.TestEquitySymbol, a.Symbol)
+	assert.Len(t, a.EarningsHistory, 4)
+	assert.Len(t, a.EarningsTrend, 2)
+	assert.Len(t, a.RecommendationTrend, 2)
+	assert.Len(t, a.UpgradesDowngrades, 3)
+	assert.NotNil(t, a.PriceTarget)
+}
+
+func TestEarningsHistory(t *testing.T) {
+	skipMock(t)
+
+	h, err := EarningsHistory(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	latest := h[len(h)-1]
+	assert.Equal(t, time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC), latest.Quarter)
+	assert.Equal(t, "-1q", latest.Period)
+	assert.Equal(t, 1.46, latest.EPSActual.Raw)
+	assert.Equal(t, 1.39, latest.EPSEstimate.Raw)
+	assert.Equal(t, "5.00%", latest.SurprisePercent.Fmt)
+	assert.True(t, h[0].SurprisePercent.Raw < 0)
+}
+
+func TestEarningsTrend(t *testing.T) {
+	skipMock(t)
+
+	a, err := GetP(&Params{Symbol: tests.TestEquitySymbol, Modules: []summary.Module{summary.EarningsTrend}})
+	assert.Nil(t, err)
+	assert.Nil(t, a.PriceTarget)
+	assert.Empty(t, a.EarningsHistory)
+
+	q := a.EarningsTrend[0]
+	assert.Equal(t, "0q", q.Period)
+	assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), q.EndDate)
+	assert.Equal(t, 2.1, q.Earnings.Avg.Raw)
+	assert.Equal(t, 1.88, q.Earnings.YearAgo.Raw)
+	assert.Equal(t, int64(27), q.Earnings.Analysts.Int())
+	assert.Equal(t, int64(117598000000), q.Revenue.Avg.Int())
+	assert.Equal(t, int64(117154000000), q.Revenue.YearAgo.Int())
+	assert.Equal(t, "USD", q.Revenue.Currency)
+	assert.Equal(t, 2.09, q.EPSTrend.NinetyDaysAgo.Raw)
+	assert.Equal(t, int64(3), q.EPSRevisions.UpLast30Days.Int())
+	assert.False(t, q.EPSRevisions.DownLast7Days.Valid)
+}
+
+func TestRecommendations(t *testing.T) {
+	skipMock(t)
+
+	r, err := Recommendations(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, &finance.Recommendation{Period: "0m", StrongBuy: 11, Buy: 21, Hold: 6}, r[0])
+}
+
+func TestUpgradesDowngrades(t *testing.T) {
+	skipMock(t)
+
+	changes, err := UpgradesDowngrades(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, &finance.RatingChange{
+		Date:      time.Date(2023, 12, 12, 0, 0, 0, 0, time.UTC),
+		Firm:      "Maxim Group",
+		FromGrade: "Buy",
+		ToGrade:   "Hold",
+		Action:    "down",
+	}, changes[0])
+}
+
+func TestPriceTarget(t *testing.T) {
+	skipMock(t)
+
+	p, err := PriceTarget(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, 250.0, p.High.Raw)
+	assert.Equal(t, 159.0, p.Low.Raw)
+	assert.Equal(t, 201.41, p.Mean.Raw)
+	assert.Equal(t, int64(38), p.Analysts.Int())
+	assert.Equal(t, "buy", p.RecommendationKey)
+}
+
+func TestBadParamsAnalysis(t *testing.T) {
+	_, err := GetP(nil)
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+
+	_, err = Get("")
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+}
+
+func TestBadSymbolAnalysis(t *testing.T) {
+	skipMock(t)
+
+	_, err := Get("BADSYMBOL")
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+// rawBackend answers every call with body.
+type rawBackend struct {
+	body string
+}
+
+func (b rawBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	return json.Unmarshal([]byte(b.body), v)
+}
+
+func TestClientOrdering(t *testing.T) {
+	c := Client{B: rawBackend{`{"quoteSummary": {"result": [{
+		"earningsHistory": {"history": [
+			{"quarter": {"raw": 1696032000}, "period": "-1q"},
+			{"quarter": {"raw": 1688083200}, "period": "-2q"}
+		]},
+		"recommendationTrend": {"trend": [
+			{"period": "-2m"}, {"period": "0m"}, {"period": "-1m"}
+		]},
+		"upgradeDowngradeHistory": {"history": [
+			{"epochGradeDate": 1690000000, "firm": "B"},
+			{"epochGradeDate": 1700000000, "firm": "A"}
+		]}
+	}], "error": null}}`}}
+
+	h, err := c.EarningsHistory("AAPL")
+	assert.Nil(t, err)
+	assert.Equal(t, "-2q", h[0].Period)
+	assert.Equal(t, "-1q", h[1].Period)
+
+	r, err := c.Recommendations("AAPL")
+	assert.Nil(t, err)
+	assert.Equal(t, "0m", r[0].Period)
+	assert.Equal(t, "-1m", r[1].Period)
+	assert.Equal(t, "-2m", r[2].Period)
+
+	changes, err := c.UpgradesDowngrades("AAPL")
+	assert.Nil(t, err)
+	assert.Equal(t, "A", changes[0].Firm)
+	assert.Equal(t, "B", changes[1].Firm)
+
+	_, err = c.PriceTarget("AAPL")
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
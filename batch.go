@@ -0,0 +1,132 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchSize is the number of symbols sent
+// in one request when Batching.BatchSize is 0.
+const DefaultBatchSize = 200
+
+// Batching splits long symbol lists across several requests,
+// since yahoo rejects URLs carrying too many symbols.
+type Batching struct {
+	// BatchSize is the most symbols sent in one request.
+	// It defaults to DefaultBatchSize.
+	BatchSize int `form:"-"`
+	// Concurrency is the number of batches requested at once.
+	// Batches are requested one at a time if it is 0 or 1.
+	Concurrency int `form:"-"`
+}
+
+// BatchFailure is a batch of symbols that could not be requested,
+// or a single symbol whose result could not be decoded.
+type BatchFailure struct {
+	Symbols []string
+	Err     error
+}
+
+// BatchError is returned when some batches of a request fail.
+// The results of the other batches are still returned.
+type BatchError struct {
+	Failures []*BatchFailure
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = fmt.Sprintf("%s: %v", strings.Join(f.Symbols, ","), f.Err)
+	}
+	return fmt.Sprintf("%d batches failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// Is reports whether the error of any failed batch matches target.
+func (e *BatchError) Is(target error) bool {
+	for _, f := range e.Failures {
+		if errors.Is(f.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of a failed batch that matches target,
+// for Go releases before 1.20 whose errors.As ignores Unwrap.
+func (e *BatchError) As(target interface{}) bool {
+	for _, f := range e.Failures {
+		if errors.As(f.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors of the failed batches.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// Batch splits symbols into batches, requests them with fetch
+// and returns the results in the order of symbols.
+// A request made of a single batch returns its error unchanged,
+// otherwise failed batches are reported through a BatchError.
+func Batch[T any](symbols []string, b Batching, fetch func(symbols []string) ([]T, error)) ([]T, error) {
+	size := b.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	if len(symbols) <= size {
+		return fetch(symbols)
+	}
+
+	var batches [][]string
+	for start := 0; start < len(symbols); start += size {
+		end := start + size
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		batches = append(batches, symbols[start:end])
+	}
+
+	limit := b.Concurrency
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+
+	results := make([][]T, len(batches))
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = fetch(batch)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var all []T
+	var failures []*BatchFailure
+	for i, batch := range batches {
+		if errs[i] != nil {
+			failures = append(failures, &BatchFailure{Symbols: batch, Err: errs[i]})
+			continue
+		}
+		all = append(all, results[i]...)
+	}
+	if len(failures) > 0 {
+		return all, &BatchError{Failures: failures}
+	}
+	return all, nil
+}
@@ -0,0 +1,70 @@
+package finance
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	symbols := []string{"A", "B", "C", "D", "E", "F", "G"}
+	var calls, inflight, peak int32
+	fetch := func(batch []string) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		// Later batches finish first.
+		time.Sleep(time.Duration('G'-batch[0][0]) * 5 * time.Millisecond)
+		return []string{strings.Join(batch, "")}, nil
+	}
+
+	results, err := Batch(symbols, Batching{BatchSize: 2, Concurrency: 3}, fetch)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"AB", "CD", "EF", "G"}, results)
+	assert.Equal(t, int32(4), calls)
+	assert.Equal(t, int32(3), peak)
+
+	calls, peak = 0, 0
+	results, err = Batch(symbols, Batching{}, fetch)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ABCDEFG"}, results)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestBatchFailures(t *testing.T) {
+	symbols := []string{"A", "B", "C", "D", "E"}
+	failure := CreateNotFoundError("C")
+	fetch := func(batch []string) ([]string, error) {
+		if batch[0] == "C" {
+			return nil, failure
+		}
+		return batch, nil
+	}
+
+	results, err := Batch(symbols, Batching{BatchSize: 2}, fetch)
+	assert.Equal(t, []string{"A", "B", "E"}, results)
+
+	var berr *BatchError
+	assert.True(t, errors.As(err, &berr))
+	assert.Len(t, berr.Failures, 1)
+	assert.Equal(t, []string{"C", "D"}, berr.Failures[0].Symbols)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	var nerr *NotFoundError
+	assert.True(t, errors.As(err, &nerr))
+	assert.Equal(t, "C", nerr.Symbol)
+	assert.Equal(t, "1 batches failed: C,D: Can't find quote for symbol: C", err.Error())
+
+	// A single batch fails with its own error.
+	_, err = Batch(symbols[2:3], Batching{BatchSize: 2}, fetch)
+	assert.Equal(t, failure, err)
+}
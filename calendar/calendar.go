@@ -0,0 +1,201 @@
+// Package calendar provides rule-based trading calendars
+// for major exchanges: their trading days, holidays,
+// early closes and session hours.
+package calendar
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Calendar describes when an exchange trades.
+// Session hours are offsets from midnight
+// in the exchange timezone.
+type Calendar struct {
+	// Name is the name of the exchange.
+	Name string
+	// Location is the exchange timezone.
+	Location *time.Location
+	// PreOpen and Open start the pre-market
+	// and regular sessions.
+	PreOpen time.Duration
+	Open    time.Duration
+	// Close and PostClose end the regular
+	// and post-market sessions.
+	Close     time.Duration
+	PostClose time.Duration
+	// EarlyClose ends the regular session on early close days.
+	EarlyClose time.Duration
+	// AlwaysOpen is set for markets that trade
+	// every day around the clock.
+	AlwaysOpen bool
+
+	rules func(year int) *year
+
+	mu    sync.Mutex
+	years map[int]*year
+}
+
+// Holiday is a day the exchange is closed
+// or closes early.
+type Holiday struct {
+	Name string
+	// Date is midnight of the holiday
+	// in the exchange timezone.
+	Date time.Time
+	// EarlyClose is set if the exchange
+	// closes early rather than not opening.
+	EarlyClose bool
+}
+
+// Session is a single trading day.
+type Session struct {
+	// Date is midnight of the trading day
+	// in the exchange timezone.
+	Date      time.Time
+	PreOpen   time.Time
+	Open      time.Time
+	Close     time.Time
+	PostClose time.Time
+	// EarlyClose is set if the regular session
+	// ends at the early close time.
+	EarlyClose bool
+}
+
+// Contains reports whether t falls in the regular session.
+func (s Session) Contains(t time.Time) bool {
+	return !t.Before(s.Open) && t.Before(s.Close)
+}
+
+// date is a calendar day.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+// year holds the holidays and early closes of a year.
+type year struct {
+	closed map[date]string
+	early  map[date]string
+}
+
+func (c *Calendar) year(n int) *year {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if y, ok := c.years[n]; ok {
+		return y
+	}
+	y := &year{closed: map[date]string{}, early: map[date]string{}}
+	if c.rules != nil {
+		y = c.rules(n)
+	}
+	if c.years == nil {
+		c.years = make(map[int]*year)
+	}
+	c.years[n] = y
+	return y
+}
+
+// IsTradingDay reports whether the exchange trades on the day
+// that t falls on in the exchange timezone.
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	_, ok := c.Session(t)
+	return ok
+}
+
+// Session returns the session on the day that t falls on
+// in the exchange timezone, or false if the exchange is closed.
+func (c *Calendar) Session(t time.Time) (Session, bool) {
+	d := dateOf(t.In(c.Location))
+	if !c.AlwaysOpen {
+		wd := time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC).Weekday()
+		if wd == time.Saturday || wd == time.Sunday {
+			return Session{}, false
+		}
+		if _, ok := c.year(d.year).closed[d]; ok {
+			return Session{}, false
+		}
+	}
+	return c.session(d), true
+}
+
+func (c *Calendar) session(d date) Session {
+	midnight := time.Date(d.year, d.month, d.day, 0, 0, 0, 0, c.Location)
+	at := func(offset time.Duration) time.Time {
+		// Offsets are wall clock times, which differ from
+		// elapsed time on days the clocks change.
+		return time.Date(d.year, d.month, d.day, 0, 0, int(offset.Seconds()), 0, c.Location)
+	}
+	s := Session{
+		Date:      midnight,
+		PreOpen:   at(c.PreOpen),
+		Open:      at(c.Open),
+		Close:     at(c.Close),
+		PostClose: at(c.PostClose),
+	}
+	if _, ok := c.year(d.year).early[d]; ok && !c.AlwaysOpen {
+		s.Close = at(c.EarlyClose)
+		s.PostClose = at(c.EarlyClose + c.PostClose - c.Close)
+		s.EarlyClose = true
+	}
+	return s
+}
+
+// NextSession returns the first session
+// whose regular hours have not ended at t.
+func (c *Calendar) NextSession(t time.Time) Session {
+	day := t.In(c.Location)
+	for {
+		if s, ok := c.Session(day); ok && t.Before(s.Close) {
+			return s
+		}
+		y, m, d := day.Date()
+		day = time.Date(y, m, d+1, 12, 0, 0, 0, c.Location)
+	}
+}
+
+// SessionsBetween returns the sessions on the days from the day
+// of start to the day of end, both included, in the exchange timezone.
+func (c *Calendar) SessionsBetween(start, end time.Time) []Session {
+	var sessions []Session
+	last := dateOf(end.In(c.Location))
+	y, m, d := start.In(c.Location).Date()
+	for day := time.Date(y, m, d, 12, 0, 0, 0, c.Location); !after(dateOf(day), last); day = day.AddDate(0, 0, 1) {
+		if s, ok := c.Session(day); ok {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions
+}
+
+// Holidays returns the holidays and early closes of a year,
+// in date order. Weekends are not included.
+func (c *Calendar) Holidays(n int) []Holiday {
+	y := c.year(n)
+	var holidays []Holiday
+	for d, name := range y.closed {
+		holidays = append(holidays, Holiday{Name: name, Date: time.Date(d.year, d.month, d.day, 0, 0, 0, 0, c.Location)})
+	}
+	for d, name := range y.early {
+		holidays = append(holidays, Holiday{Name: name, Date: time.Date(d.year, d.month, d.day, 0, 0, 0, 0, c.Location), EarlyClose: true})
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+func after(a, b date) bool {
+	if a.year != b.year {
+		return a.year > b.year
+	}
+	if a.month != b.month {
+		return a.month > b.month
+	}
+	return a.day > b.day
+}
@@ -0,0 +1,94 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dates(c *Calendar, year int, early bool) []string {
+	var ds []string
+	for _, h := range c.Holidays(year) {
+		if h.EarlyClose == early {
+			ds = append(ds, h.Date.Format("01-02"))
+		}
+	}
+	return ds
+}
+
+func TestHolidays(t *testing.T) {
+	assert.Equal(t, []string{"01-01", "01-15", "02-19", "03-29", "05-27", "06-19", "07-04", "09-02", "11-28", "12-25"}, dates(NYSE, 2024, false))
+	assert.Equal(t, []string{"07-03", "11-29", "12-24"}, dates(NYSE, 2024, true))
+	assert.Equal(t, []string{"01-17", "02-21", "04-15", "05-30", "06-20", "07-04", "09-05", "11-24", "12-26"}, dates(NASDAQ, 2022, false))
+
+	assert.Equal(t, []string{"01-01", "03-29", "04-01", "05-06", "05-27", "08-26", "12-25", "12-26"}, dates(LSE, 2024, false))
+	assert.Equal(t, []string{"12-24", "12-31"}, dates(LSE, 2024, true))
+	assert.Equal(t, []string{"01-01", "04-02", "04-05", "05-03", "05-31", "08-30", "12-27", "12-28"}, dates(LSE, 2021, false))
+
+	assert.Equal(t, []string{"01-01", "02-19", "03-29", "05-20", "07-01", "08-05", "09-02", "10-14", "12-25", "12-26"}, dates(TSX, 2024, false))
+	assert.Equal(t, []string{"01-01", "03-29", "04-01", "05-01", "12-24", "12-25", "12-26", "12-31"}, dates(XETRA, 2024, false))
+
+	assert.Equal(t, []string{"01-01", "01-02", "01-03", "01-08", "02-12", "02-23", "03-20", "04-29", "05-03", "05-06",
+		"07-15", "08-12", "09-16", "09-23", "10-14", "11-04", "12-31"}, dates(JPX, 2024, false))
+	assert.Contains(t, dates(JPX, 2026, false), "09-22")
+
+	assert.Empty(t, Crypto.Holidays(2024))
+}
+
+func TestSessions(t *testing.T) {
+	july3 := time.Date(2024, 7, 3, 12, 0, 0, 0, time.UTC)
+	s, ok := NYSE.Session(july3)
+	assert.True(t, ok)
+	assert.True(t, s.EarlyClose)
+	assert.Equal(t, time.Date(2024, 7, 3, 13, 30, 0, 0, time.UTC), s.Open.UTC())
+	assert.Equal(t, time.Date(2024, 7, 3, 17, 0, 0, 0, time.UTC), s.Close.UTC())
+	assert.Equal(t, time.Date(2024, 7, 3, 21, 0, 0, 0, time.UTC), s.PostClose.UTC())
+	assert.True(t, s.Contains(time.Date(2024, 7, 3, 14, 0, 0, 0, time.UTC)))
+
+	// Session hours follow daylight saving time.
+	s, _ = NYSE.Session(time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, 14, s.Open.UTC().Hour())
+	s, _ = NYSE.Session(time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, 13, s.Open.UTC().Hour())
+
+	assert.False(t, NYSE.IsTradingDay(time.Date(2024, 7, 4, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, NYSE.IsTradingDay(time.Date(2024, 7, 6, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, Crypto.IsTradingDay(time.Date(2024, 7, 6, 12, 0, 0, 0, time.UTC)))
+	// Days are those of the exchange timezone.
+	assert.True(t, JPX.IsTradingDay(time.Date(2024, 7, 15, 22, 0, 0, 0, time.UTC)))
+	assert.False(t, JPX.IsTradingDay(time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestNextSession(t *testing.T) {
+	friday := time.Date(2024, 3, 28, 21, 0, 0, 0, time.UTC)
+	s := NYSE.NextSession(friday)
+	assert.Equal(t, "2024-04-01", s.Date.Format("2006-01-02"))
+
+	s = NYSE.NextSession(time.Date(2024, 4, 1, 15, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-04-01", s.Date.Format("2006-01-02"))
+
+	s = Crypto.NextSession(time.Date(2024, 4, 6, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-04-06", s.Date.Format("2006-01-02"))
+	assert.Equal(t, time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC), s.Close)
+}
+
+func TestSessionsBetween(t *testing.T) {
+	sessions := NYSE.SessionsBetween(time.Date(2024, 12, 23, 0, 0, 0, 0, NYSE.Location), time.Date(2024, 12, 29, 0, 0, 0, 0, NYSE.Location))
+	var days []string
+	for _, s := range sessions {
+		days = append(days, s.Date.Format("01-02"))
+	}
+	assert.Equal(t, []string{"12-23", "12-24", "12-26", "12-27"}, days)
+	assert.True(t, sessions[1].EarlyClose)
+
+	assert.Empty(t, NYSE.SessionsBetween(time.Date(2024, 12, 28, 0, 0, 0, 0, NYSE.Location), time.Date(2024, 12, 29, 0, 0, 0, 0, NYSE.Location)))
+	assert.Len(t, Crypto.SessionsBetween(time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC)), 2)
+}
+
+func TestForExchange(t *testing.T) {
+	assert.Equal(t, NYSE, ForExchange("NYQ"))
+	assert.Equal(t, NASDAQ, ForExchange("NMS"))
+	assert.Equal(t, Crypto, ForExchange("CCC"))
+	assert.Nil(t, ForExchange("XXX"))
+}
@@ -0,0 +1,402 @@
+package calendar
+
+import (
+	"time"
+)
+
+// The exchange calendars follow the published holiday rules.
+// One-off closures, such as national days of mourning, are not known.
+var (
+	// NYSE is the New York Stock Exchange.
+	NYSE = &Calendar{
+		Name:       "NYSE",
+		Location:   load("America/New_York", -5),
+		PreOpen:    clock(4, 0),
+		Open:       clock(9, 30),
+		Close:      clock(16, 0),
+		PostClose:  clock(20, 0),
+		EarlyClose: clock(13, 0),
+		rules:      usRules,
+	}
+	// NASDAQ trades on the same days and hours as NYSE.
+	NASDAQ = &Calendar{
+		Name:       "NASDAQ",
+		Location:   NYSE.Location,
+		PreOpen:    clock(4, 0),
+		Open:       clock(9, 30),
+		Close:      clock(16, 0),
+		PostClose:  clock(20, 0),
+		EarlyClose: clock(13, 0),
+		rules:      usRules,
+	}
+	// LSE is the London Stock Exchange.
+	LSE = &Calendar{
+		Name:       "LSE",
+		Location:   load("Europe/London", 0),
+		PreOpen:    clock(8, 0),
+		Open:       clock(8, 0),
+		Close:      clock(16, 30),
+		PostClose:  clock(16, 30),
+		EarlyClose: clock(12, 30),
+		rules:      ukRules,
+	}
+	// TSX is the Toronto Stock Exchange.
+	TSX = &Calendar{
+		Name:       "TSX",
+		Location:   load("America/Toronto", -5),
+		PreOpen:    clock(9, 30),
+		Open:       clock(9, 30),
+		Close:      clock(16, 0),
+		PostClose:  clock(16, 0),
+		EarlyClose: clock(13, 0),
+		rules:      caRules,
+	}
+	// XETRA is the electronic trading venue of the Frankfurt Stock Exchange.
+	XETRA = &Calendar{
+		Name:       "XETRA",
+		Location:   load("Europe/Berlin", 1),
+		PreOpen:    clock(9, 0),
+		Open:       clock(9, 0),
+		Close:      clock(17, 30),
+		PostClose:  clock(17, 30),
+		EarlyClose: clock(14, 0),
+		rules:      deRules,
+	}
+	// JPX is the Tokyo Stock Exchange.
+	JPX = &Calendar{
+		Name:       "JPX",
+		Location:   load("Asia/Tokyo", 9),
+		PreOpen:    clock(9, 0),
+		Open:       clock(9, 0),
+		Close:      clock(15, 30),
+		PostClose:  clock(15, 30),
+		EarlyClose: clock(15, 30),
+		rules:      jpRules,
+	}
+	// Crypto trades around the clock every day, in UTC.
+	Crypto = &Calendar{
+		Name:       "Crypto",
+		Location:   time.UTC,
+		Close:      24 * time.Hour,
+		PostClose:  24 * time.Hour,
+		EarlyClose: 24 * time.Hour,
+		AlwaysOpen: true,
+	}
+)
+
+// exchanges maps yahoo exchange codes to calendars.
+var exchanges = map[string]*Calendar{
+	"NYQ": NYSE,
+	"NYS": NYSE,
+	"ASE": NYSE,
+	"PCX": NYSE,
+	"BTS": NYSE,
+	"NMS": NASDAQ,
+	"NGM": NASDAQ,
+	"NCM": NASDAQ,
+	"NAS": NASDAQ,
+	"NIM": NASDAQ,
+	"LSE": LSE,
+	"IOB": LSE,
+	"TOR": TSX,
+	"GER": XETRA,
+	"JPX": JPX,
+	"TYO": JPX,
+	"CCC": Crypto,
+	"CCY": Crypto,
+}
+
+// ForExchange returns the calendar of a yahoo exchange code,
+// as found in Quote.ExchangeID or ChartMeta.ExchangeName,
+// or nil if it is not known.
+func ForExchange(code string) *Calendar {
+	return exchanges[code]
+}
+
+// usRules are the NYSE and NASDAQ holidays.
+func usRules(y int) *year {
+	r := newRules()
+	r.close(sundayToMonday(date{y, time.January, 1}), "New Year's Day")
+	if y >= 1998 {
+		r.close(nthWeekday(y, time.January, time.Monday, 3), "Martin Luther King Jr. Day")
+	}
+	r.close(nthWeekday(y, time.February, time.Monday, 3), "Washington's Birthday")
+	r.close(easter(y).add(-2), "Good Friday")
+	r.close(nthWeekday(y, time.May, time.Monday, -1), "Memorial Day")
+	if y >= 2022 {
+		r.close(nearestWeekday(date{y, time.June, 19}), "Juneteenth")
+	}
+	july4 := date{y, time.July, 4}
+	r.close(nearestWeekday(july4), "Independence Day")
+	if wd := july4.weekday(); wd >= time.Tuesday && wd <= time.Friday {
+		r.earlyClose(july4.add(-1), "Independence Day")
+	}
+	r.close(nthWeekday(y, time.September, time.Monday, 1), "Labor Day")
+	thanksgiving := nthWeekday(y, time.November, time.Thursday, 4)
+	r.close(thanksgiving, "Thanksgiving Day")
+	r.earlyClose(thanksgiving.add(1), "Thanksgiving Day")
+	christmas := date{y, time.December, 25}
+	r.close(nearestWeekday(christmas), "Christmas Day")
+	if wd := christmas.weekday(); wd >= time.Tuesday && wd <= time.Friday {
+		r.earlyClose(christmas.add(-1), "Christmas Eve")
+	}
+	return r.year
+}
+
+// ukRules are the LSE holidays.
+func ukRules(y int) *year {
+	r := newRules()
+	r.close(weekendToMonday(date{y, time.January, 1}), "New Year's Day")
+	e := easter(y)
+	r.close(e.add(-2), "Good Friday")
+	r.close(e.add(1), "Easter Monday")
+	r.close(nthWeekday(y, time.May, time.Monday, 1), "Early May Bank Holiday")
+	r.close(nthWeekday(y, time.May, time.Monday, -1), "Spring Bank Holiday")
+	r.close(nthWeekday(y, time.August, time.Monday, -1), "Summer Bank Holiday")
+	christmas, boxing := christmasPair(y)
+	r.close(christmas, "Christmas Day")
+	r.close(boxing, "Boxing Day")
+	r.earlyClose(date{y, time.December, 24}, "Christmas Eve")
+	r.earlyClose(date{y, time.December, 31}, "New Year's Eve")
+	return r.year
+}
+
+// caRules are the TSX holidays.
+func caRules(y int) *year {
+	r := newRules()
+	r.close(weekendToMonday(date{y, time.January, 1}), "New Year's Day")
+	if y >= 2008 {
+		r.close(nthWeekday(y, time.February, time.Monday, 3), "Family Day")
+	}
+	r.close(easter(y).add(-2), "Good Friday")
+	// Victoria Day is the last Monday before May 25.
+	victoria := date{y, time.May, 24}
+	for victoria.weekday() != time.Monday {
+		victoria = victoria.add(-1)
+	}
+	r.close(victoria, "Victoria Day")
+	r.close(weekendToMonday(date{y, time.July, 1}), "Canada Day")
+	r.close(nthWeekday(y, time.August, time.Monday, 1), "Civic Holiday")
+	r.close(nthWeekday(y, time.September, time.Monday, 1), "Labour Day")
+	r.close(nthWeekday(y, time.October, time.Monday, 2), "Thanksgiving Day")
+	christmas, boxing := christmasPair(y)
+	r.close(christmas, "Christmas Day")
+	r.close(boxing, "Boxing Day")
+	r.earlyClose(date{y, time.December, 24}, "Christmas Eve")
+	return r.year
+}
+
+// deRules are the XETRA holidays.
+func deRules(y int) *year {
+	r := newRules()
+	r.close(date{y, time.January, 1}, "New Year's Day")
+	e := easter(y)
+	r.close(e.add(-2), "Good Friday")
+	r.close(e.add(1), "Easter Monday")
+	r.close(date{y, time.May, 1}, "Labour Day")
+	r.close(date{y, time.December, 24}, "Christmas Eve")
+	r.close(date{y, time.December, 25}, "Christmas Day")
+	r.close(date{y, time.December, 26}, "Boxing Day")
+	r.close(date{y, time.December, 31}, "New Year's Eve")
+	return r.year
+}
+
+// jpRules are the JPX holidays: the year-end break
+// and the national holidays of Japan.
+func jpRules(y int) *year {
+	r := newRules()
+	national := map[date]bool{}
+	holiday := func(d date, name string) {
+		national[d] = true
+		r.close(d, name)
+	}
+	holiday(date{y, time.January, 1}, "New Year's Day")
+	holiday(nthWeekday(y, time.January, time.Monday, 2), "Coming of Age Day")
+	holiday(date{y, time.February, 11}, "National Foundation Day")
+	if y >= 2020 {
+		holiday(date{y, time.February, 23}, "Emperor's Birthday")
+	} else if y <= 2018 {
+		holiday(date{y, time.December, 23}, "Emperor's Birthday")
+	}
+	holiday(equinox(y, 20.8431), "Vernal Equinox Day")
+	holiday(date{y, time.April, 29}, "Showa Day")
+	holiday(date{y, time.May, 3}, "Constitution Memorial Day")
+	holiday(date{y, time.May, 4}, "Greenery Day")
+	holiday(date{y, time.May, 5}, "Children's Day")
+	holiday(nthWeekday(y, time.July, time.Monday, 3), "Marine Day")
+	if y >= 2016 {
+		holiday(date{y, time.August, 11}, "Mountain Day")
+	}
+	aged := nthWeekday(y, time.September, time.Monday, 3)
+	holiday(aged, "Respect for the Aged Day")
+	autumn := equinox(y, 23.2488)
+	holiday(autumn, "Autumnal Equinox Day")
+	holiday(nthWeekday(y, time.October, time.Monday, 2), "Sports Day")
+	holiday(date{y, time.November, 3}, "Culture Day")
+	holiday(date{y, time.November, 23}, "Labor Thanksgiving Day")
+
+	// A day between two holidays is a holiday too.
+	if autumn.add(-2) == aged {
+		holiday(aged.add(1), "Citizens' Holiday")
+	}
+	// A holiday on a Sunday moves to the next day
+	// that is not a holiday already.
+	for d := range national {
+		if d.weekday() != time.Sunday {
+			continue
+		}
+		next := d.add(1)
+		for national[next] {
+			next = next.add(1)
+		}
+		r.close(next, "Substitute Holiday")
+	}
+
+	// The exchange also closes for the year-end break.
+	r.close(date{y, time.January, 2}, "Market Holiday")
+	r.close(date{y, time.January, 3}, "Market Holiday")
+	r.close(date{y, time.December, 31}, "Market Holiday")
+	return r.year
+}
+
+// rules collects the holidays of a year.
+type rules struct {
+	*year
+}
+
+func newRules() rules {
+	return rules{&year{closed: map[date]string{}, early: map[date]string{}}}
+}
+
+func (r rules) close(d date, name string) {
+	if d.weekday() == time.Saturday || d.weekday() == time.Sunday {
+		return
+	}
+	if _, ok := r.closed[d]; !ok {
+		r.closed[d] = name
+	}
+}
+
+func (r rules) isClosed(d date) bool {
+	_, ok := r.closed[d]
+	return ok
+}
+
+func (r rules) earlyClose(d date, name string) {
+	if d.weekday() == time.Saturday || d.weekday() == time.Sunday || r.isClosed(d) {
+		return
+	}
+	r.early[d] = name
+}
+
+func (d date) time() time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)
+}
+
+func (d date) weekday() time.Weekday {
+	return d.time().Weekday()
+}
+
+func (d date) add(days int) date {
+	return dateOf(d.time().AddDate(0, 0, days))
+}
+
+// nthWeekday returns the nth weekday of a month,
+// or the last one if n is -1.
+func nthWeekday(y int, m time.Month, wd time.Weekday, n int) date {
+	if n < 0 {
+		d := date{y, m + 1, 1}.add(-1)
+		for d.weekday() != wd {
+			d = d.add(-1)
+		}
+		return d
+	}
+	d := date{y, m, 1}
+	for d.weekday() != wd {
+		d = d.add(1)
+	}
+	return d.add(7 * (n - 1))
+}
+
+// nearestWeekday moves a Saturday to Friday
+// and a Sunday to Monday.
+func nearestWeekday(d date) date {
+	switch d.weekday() {
+	case time.Saturday:
+		return d.add(-1)
+	case time.Sunday:
+		return d.add(1)
+	}
+	return d
+}
+
+// sundayToMonday moves a Sunday to Monday.
+func sundayToMonday(d date) date {
+	if d.weekday() == time.Sunday {
+		return d.add(1)
+	}
+	return d
+}
+
+// weekendToMonday moves a Saturday or Sunday to Monday.
+func weekendToMonday(d date) date {
+	for d.weekday() == time.Saturday || d.weekday() == time.Sunday {
+		d = d.add(1)
+	}
+	return d
+}
+
+// christmasPair returns the observed Christmas and Boxing days,
+// moved past the weekend without falling on the same day.
+func christmasPair(y int) (date, date) {
+	christmas := weekendToMonday(date{y, time.December, 25})
+	boxing := weekendToMonday(date{y, time.December, 26})
+	if boxing == christmas {
+		boxing = boxing.add(1)
+	}
+	return christmas, boxing
+}
+
+// easter returns Easter Sunday of the Gregorian calendar.
+func easter(y int) date {
+	a := y % 19
+	b := y / 100
+	c := y % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date{y, time.Month(month), day}
+}
+
+// equinox returns the vernal (base 20.8431) or autumnal
+// (base 23.2488) equinox day in Japan, valid until 2099.
+func equinox(y int, base float64) date {
+	month := time.March
+	if base > 21 {
+		month = time.September
+	}
+	day := int(base+0.242194*float64(y-1980)) - (y-1980)/4
+	return date{y, month, day}
+}
+
+func clock(hour, minute int) time.Duration {
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+}
+
+// load returns the named location, or a fixed zone with the
+// standard offset in hours if the timezone database is missing.
+func load(name string, offset int) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone(name, offset*60*60)
+	}
+	return loc
+}
@@ -0,0 +1,280 @@
+// Package cassette provides a finance.Backend that records the responses
+// of another backend to an on-disk cassette and replays them later,
+// so that tests can run deterministically without network access.
+//
+// A cassette is a JSON file holding one interaction per distinct request.
+// Requests are keyed by their path, encoded parameters and POST body.
+// The crumb parameter and anything cookie related are never written to disk.
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+)
+
+// Mode controls whether a cassette records, replays or both.
+type Mode int
+
+const (
+	// ModeReplay only replays recorded interactions. Requests without
+	// a recorded interaction fail with ErrMissing.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the wrapped backend and
+	// records its response, replacing any earlier recording.
+	ModeRecord
+	// ModeRecordMissing replays recorded interactions and sends
+	// the other requests to the wrapped backend, recording them.
+	ModeRecordMissing
+)
+
+// ErrMissing is returned in replay mode for
+// requests that have no recorded interaction.
+var ErrMissing = errors.New("cassette: no recorded interaction")
+
+// redactedParams are request parameters never written to a cassette.
+var redactedParams = []string{"crumb"}
+
+// Interaction is a single recorded request and its outcome.
+type Interaction struct {
+	// Path is the request path.
+	Path string `json:"path"`
+	// Query is the encoded request parameters, redacted.
+	Query string `json:"query,omitempty"`
+	// Body is the JSON body of a POST request.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Response is the raw JSON body of a successful response.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error describes a failed request.
+	Error *RecordedError `json:"error,omitempty"`
+}
+
+// RecordedError is a recorded HTTP error response. Other failures,
+// such as timeouts or refused connections, are never recorded.
+type RecordedError struct {
+	// StatusCode is the response status.
+	StatusCode int `json:"statusCode"`
+	// Body is the response body snippet.
+	Body string `json:"body,omitempty"`
+	// Upstream is the error object found in the response body, if any.
+	Upstream *finance.YfinError `json:"upstream,omitempty"`
+}
+
+// Backend is a finance.Backend recording to, or
+// replaying from, a cassette file.
+// It is safe for concurrent use.
+type Backend struct {
+	// B is the backend that recorded requests are sent to.
+	// It is not used in ModeReplay.
+	B finance.Backend
+
+	path         string
+	mode         Mode
+	mu           sync.Mutex
+	interactions map[string]*Interaction
+}
+
+// New returns a Backend for the cassette file at path, wrapping b.
+// An existing cassette is loaded; a missing one is created when the
+// first interaction is recorded.
+func New(path string, mode Mode, b finance.Backend) (*Backend, error) {
+	c := &Backend{
+		B:            b,
+		path:         path,
+		mode:         mode,
+		interactions: make(map[string]*Interaction),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Interaction
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("cassette: cannot parse %s: %v", path, err)
+	}
+	for _, in := range list {
+		c.interactions[key(in.Path, in.Query, in.Body)] = in
+	}
+	return c, nil
+}
+
+// Call implements finance.Backend.
+func (c *Backend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	return c.do(path, body, nil, ctx, v, func(raw *json.RawMessage) error {
+		return c.B.Call(path, body, ctx, raw)
+	})
+}
+
+// Post implements finance.PostBackend.
+// The wrapped backend must implement it to record POST requests.
+func (c *Backend) Post(path string, params *form.Values, body interface{}, ctx *context.Context, v interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.do(path, params, data, ctx, v, func(raw *json.RawMessage) error {
+		return finance.Post(c.B, path, params, body, ctx, raw)
+	})
+}
+
+// do replays the interaction of a request, or records it with send.
+func (c *Backend) do(path string, params *form.Values, body []byte, ctx *context.Context, v interface{}, send func(*json.RawMessage) error) error {
+	if ctx != nil && (*ctx).Err() != nil {
+		return (*ctx).Err()
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	query := redact(params)
+	k := key(path, query, body)
+
+	if c.mode != ModeRecord {
+		c.mu.Lock()
+		in, ok := c.interactions[k]
+		c.mu.Unlock()
+		if ok {
+			return replay(in, v)
+		}
+		if c.mode == ModeReplay {
+			return fmt.Errorf("%w: %s", ErrMissing, k)
+		}
+	}
+
+	if c.B == nil {
+		return errors.New("cassette: no backend to record from")
+	}
+
+	var raw json.RawMessage
+	in := &Interaction{Path: path, Query: query, Body: body}
+	if err := send(&raw); err != nil {
+		// Only responses of the remote api are recorded, not
+		// cancellations or failures to reach it.
+		var herr *finance.HTTPError
+		if !errors.As(err, &herr) {
+			return err
+		}
+		in.Error = &RecordedError{StatusCode: herr.StatusCode, Body: herr.Body, Upstream: herr.Upstream}
+	} else {
+		in.Response = raw
+	}
+
+	if err := c.save(k, in); err != nil {
+		return err
+	}
+	return replay(in, v)
+}
+
+// Interactions returns the recorded interactions, sorted by request.
+func (c *Backend) Interactions() []*Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sorted()
+}
+
+// save adds an interaction and rewrites the cassette file.
+func (c *Backend) save(k string, in *Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions[k] = in
+
+	data, err := json.MarshalIndent(c.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// sorted returns the interactions ordered by key.
+// The caller must hold c.mu.
+func (c *Backend) sorted() []*Interaction {
+	keys := make([]string, 0, len(c.interactions))
+	for k := range c.interactions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]*Interaction, len(keys))
+	for i, k := range keys {
+		list[i] = c.interactions[k]
+	}
+	return list
+}
+
+// replay decodes a recorded interaction into v.
+func replay(in *Interaction, v interface{}) error {
+	if e := in.Error; e != nil {
+		return httpError(in, e)
+	}
+	if v == nil || len(in.Response) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(in.Response, v); err != nil {
+		return finance.CreateDecodeError(err, in.Response)
+	}
+	return nil
+}
+
+// httpError rebuilds the HTTPError of a recorded interaction.
+func httpError(in *Interaction, e *RecordedError) error {
+	u := in.Path
+	if in.Query != "" {
+		u += "?" + in.Query
+	}
+	return &finance.HTTPError{StatusCode: e.StatusCode, URL: u, Body: e.Body, Upstream: e.Upstream}
+}
+
+// redact encodes body without the redacted parameters,
+// in a stable order.
+func redact(body *form.Values) string {
+	if body == nil || body.Empty() {
+		return ""
+	}
+	values := body.ToValues()
+	for _, p := range redactedParams {
+		values.Del(p)
+	}
+	for k := range values {
+		if strings.Contains(strings.ToLower(k), "cookie") {
+			values.Del(k)
+		}
+	}
+	return values.Encode()
+}
+
+// key identifies a request in a cassette.
+func key(path, query string, body []byte) string {
+	k := path
+	if query != "" {
+		k += "?" + query
+	}
+	if len(body) > 0 {
+		// Saved bodies are indented with the rest of the cassette.
+		var b bytes.Buffer
+		if json.Compact(&b, body) == nil {
+			body = b.Bytes()
+		}
+		k += " " + string(body)
+	}
+	return k
+}
@@ -0,0 +1,221 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/assert"
+)
+
+type quoteResponse struct {
+	Inner struct {
+		Result []*finance.Quote `json:"result"`
+	} `json:"quoteResponse"`
+}
+
+// upstream serves a single quote, or a 404 for unknown paths.
+func upstream() (*httptest.Server, *int32, finance.Backend) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/v7/finance/quote" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`))
+			return
+		}
+		w.Write([]byte(`{"quoteResponse":{"result":[{"symbol":"` + r.URL.Query().Get("symbols") + `"}]}}`))
+	}))
+	b := &finance.BackendConfiguration{Type: finance.YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+	return srv, &hits, b
+}
+
+func params(symbol string) *form.Values {
+	body := &form.Values{}
+	body.Set("symbols", symbol)
+	body.Set("crumb", "secret")
+	return body
+}
+
+func TestRecordMissingThenReplay(t *testing.T) {
+	srv, hits, b := upstream()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "fixtures", "quote.json")
+
+	c, err := New(path, ModeRecordMissing, b)
+	assert.Nil(t, err)
+
+	resp := quoteResponse{}
+	assert.Nil(t, c.Call("/v7/finance/quote", params("AAPL"), nil, &resp))
+	assert.Equal(t, "AAPL", resp.Inner.Result[0].Symbol)
+	assert.Nil(t, c.Call("/v7/finance/quote", params("AAPL"), nil, &resp))
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+
+	// A fresh replay-only cassette needs no backend at all.
+	srv.Close()
+	r, err := New(path, ModeReplay, nil)
+	assert.Nil(t, err)
+	resp = quoteResponse{}
+	assert.Nil(t, r.Call("v7/finance/quote", params("AAPL"), nil, &resp))
+	assert.Equal(t, "AAPL", resp.Inner.Result[0].Symbol)
+}
+
+func TestReplayMissing(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), "empty.json"), ModeReplay, nil)
+	assert.Nil(t, err)
+
+	err = c.Call("/v7/finance/quote", params("AAPL"), nil, nil)
+	assert.True(t, errors.Is(err, ErrMissing))
+	assert.True(t, strings.Contains(err.Error(), "symbols=AAPL"))
+}
+
+func TestRecordOverwrites(t *testing.T) {
+	srv, hits, b := upstream()
+	defer srv.Close()
+	c, err := New(filepath.Join(t.TempDir(), "quote.json"), ModeRecord, b)
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.Call("/v7/finance/quote", params("AAPL"), nil, nil))
+	assert.Nil(t, c.Call("/v7/finance/quote", params("AAPL"), nil, nil))
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+	assert.Len(t, c.Interactions(), 1)
+}
+
+func TestRedaction(t *testing.T) {
+	srv, _, b := upstream()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "quote.json")
+	c, err := New(path, ModeRecordMissing, b)
+	assert.Nil(t, err)
+
+	body := params("AAPL")
+	body.Set("Cookie", "B=secret")
+	assert.Nil(t, c.Call("/v7/finance/quote", body, nil, nil))
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), "secret"))
+	assert.Equal(t, "symbols=AAPL", c.Interactions()[0].Query)
+
+	// The crumb does not take part in matching either.
+	r, err := New(path, ModeReplay, nil)
+	assert.Nil(t, err)
+	body = params("AAPL")
+	body.Set("crumb", "another")
+	assert.Nil(t, r.Call("/v7/finance/quote", body, nil, nil))
+}
+
+func TestRecordErrors(t *testing.T) {
+	srv, _, b := upstream()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "chart.json")
+	c, err := New(path, ModeRecordMissing, b)
+	assert.Nil(t, err)
+
+	err = c.Call("/v8/finance/chart/BADSYMBOL", nil, nil, nil)
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+
+	srv.Close()
+	r, err := New(path, ModeReplay, nil)
+	assert.Nil(t, err)
+	err = r.Call("/v8/finance/chart/BADSYMBOL", nil, nil, nil)
+
+	var herr *finance.HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusNotFound, herr.StatusCode)
+	assert.Equal(t, "Not Found", herr.Upstream.Code)
+}
+
+func TestRecordLongError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"finance":{"result":null,"error":{"code":"Bad Request","description":"` + strings.Repeat("x", 4096) + `"}}}`))
+	}))
+	b := &finance.BackendConfiguration{Type: finance.YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+	path := filepath.Join(t.TempDir(), "quote.json")
+	c, err := New(path, ModeRecordMissing, b)
+	assert.Nil(t, err)
+	assert.NotNil(t, c.Call("/v7/finance/quote", params("AAPL"), nil, nil))
+
+	srv.Close()
+	r, err := New(path, ModeReplay, nil)
+	assert.Nil(t, err)
+	err = r.Call("/v7/finance/quote", params("AAPL"), nil, nil)
+
+	var herr *finance.HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.NotNil(t, herr.Upstream)
+	assert.Equal(t, "Bad Request", herr.Upstream.Code)
+}
+
+func TestTransportErrorNotRecorded(t *testing.T) {
+	srv, _, b := upstream()
+	srv.Close()
+	path := filepath.Join(t.TempDir(), "quote.json")
+	c, err := New(path, ModeRecordMissing, b)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, c.Call("/v7/finance/quote", params("AAPL"), nil, nil))
+	assert.Empty(t, c.Interactions())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRecordPost(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		data, _ := io.ReadAll(r.Body)
+		w.Write([]byte(`{"method":"` + r.Method + `","body":` + string(data) + `}`))
+	}))
+	defer srv.Close()
+	b := &finance.BackendConfiguration{Type: finance.YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+
+	path := filepath.Join(t.TempDir(), "screener.json")
+	c, err := New(path, ModeRecordMissing, b)
+	assert.Nil(t, err)
+
+	var resp struct {
+		Method string         `json:"method"`
+		Body   map[string]int `json:"body"`
+	}
+	assert.Nil(t, c.Post("/v1/finance/screener", nil, map[string]int{"offset": 0}, nil, &resp))
+	assert.Nil(t, c.Post("/v1/finance/screener", nil, map[string]int{"offset": 25}, nil, &resp))
+	assert.Equal(t, "POST", resp.Method)
+	assert.Equal(t, 25, resp.Body["offset"])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+
+	// Requests with different bodies are replayed separately.
+	r, err := New(path, ModeReplay, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, finance.Post(r, "/v1/finance/screener", nil, map[string]int{"offset": 0}, nil, &resp))
+	assert.Equal(t, 0, resp.Body["offset"])
+	err = r.Post("/v1/finance/screener", nil, map[string]int{"offset": 50}, nil, nil)
+	assert.True(t, errors.Is(err, ErrMissing))
+}
+
+func TestCanceledContext(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), "quote.json"), ModeReplay, nil)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, c.Call("/v7/finance/quote", nil, &ctx, nil))
+}
+
+func TestBadCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	assert.Nil(t, os.WriteFile(path, []byte("{"), 0644))
+
+	_, err := New(path, ModeReplay, nil)
+	assert.NotNil(t, err)
+}
@@ -2,8 +2,14 @@ package chart
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
 	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/calendar"
 	"github.com/piquette/finance-go/datetime"
 	form "github.com/piquette/finance-go/form"
 	"github.com/piquette/finance-go/iter"
@@ -29,32 +35,106 @@ type Params struct {
 	Start    *datetime.Datetime `form:"-"`
 	End      *datetime.Datetime `form:"-"`
 	Interval datetime.Interval  `form:"-"`
+	// Range requests the span ending at the most recent bar.
+	// It cannot be combined with Start or End.
+	Range datetime.Range `form:"-"`
+	// Timezone is the IANA name of the timezone date-only
+	// Start and End values are read in. It defaults to the
+	// timezone of the exchange.
+	Timezone string `form:"-"`
+	// Calendar aligns date-only Start and End values to the
+	// sessions of the exchange, and is used for their timezone
+	// if Timezone is empty.
+	Calendar *calendar.Calendar `form:"-"`
 
-	IncludeExt bool `form:"includePrePost"`
+	IncludeExt bool      `form:"includePrePost"`
+	Gaps       GapPolicy `form:"-"`
+	Events     []Event   `form:"-"`
+
+	// Concurrency is the number of requests made at once
+	// when a long intraday range is split into windows.
+	// Windows are fetched one at a time if it is 0 or 1.
+	Concurrency int `form:"-"`
 
 	// Internal request fields.
 	interval string `form:"interval"`
+	rng      string `form:"range"`
+	events   string `form:"events"`
 	start    int    `form:"period1"`
 	end      int    `form:"period2"`
 }
 
+// GapPolicy selects how bars that yahoo
+// reports without prices are returned.
+type GapPolicy int
+
+const (
+	// KeepGaps returns gap bars with zero prices
+	// and Valid set to false.
+	KeepGaps GapPolicy = iota
+	// DropGaps omits gap bars.
+	DropGaps
+	// FillGaps carries the close of the last valid bar
+	// forward into gap bars, which keep Valid set to false.
+	// Gaps before the first valid bar are omitted.
+	FillGaps
+)
+
+// Event is a kind of corporate action
+// that can be requested along with a chart.
+type Event string
+
+const (
+	// DividendEvents requests dividends.
+	DividendEvents Event = "div"
+	// SplitEvents requests stock splits.
+	SplitEvents Event = "splits"
+	// CapitalGainEvents requests capital gain distributions.
+	CapitalGainEvents Event = "capitalGains"
+)
+
 // Iter is a structure containing results
 // and related metadata for a
 // yfin chart request.
 type Iter struct {
-	*iter.Iter
+	*iter.Of[*finance.ChartBar]
+	dividends    []*finance.Dividend
+	splits       []*finance.Split
+	capitalGains []*finance.CapitalGain
 }
 
 // Bar returns the next Bar
 // visited by a call to Next.
 func (i *Iter) Bar() *finance.ChartBar {
-	return i.Current().(*finance.ChartBar)
+	return i.Current()
 }
 
 // Meta returns the chart metadata
 // related to a chart response.
 func (i *Iter) Meta() finance.ChartMeta {
-	return i.Iter.Meta().(finance.ChartMeta)
+	meta, _ := i.Of.Meta().(finance.ChartMeta)
+	return meta
+}
+
+// Dividends returns the dividends requested
+// through Params.Events, oldest first.
+// They are complete once Next has returned false.
+func (i *Iter) Dividends() []*finance.Dividend {
+	return i.dividends
+}
+
+// Splits returns the stock splits requested
+// through Params.Events, oldest first.
+// They are complete once Next has returned false.
+func (i *Iter) Splits() []*finance.Split {
+	return i.splits
+}
+
+// CapitalGains returns the capital gains requested
+// through Params.Events, oldest first.
+// They are complete once Next has returned false.
+func (i *Iter) CapitalGains() []*finance.CapitalGain {
+	return i.capitalGains
 }
 
 // Get returns a historical chart.
@@ -65,12 +145,13 @@ func Get(params *Params) *Iter {
 }
 
 // Get returns a historical chart.
+// Nothing is requested until the first call to Next.
 func (c Client) Get(params *Params) *Iter {
 
 	// Construct request from params input.
 	// TODO: validate symbol..
 	if params == nil || len(params.Symbol) == 0 {
-		return &Iter{iter.NewE(finance.CreateArgumentError())}
+		return &Iter{Of: iter.NewOfE[*finance.ChartBar](finance.CreateArgumentError())}
 	}
 
 	if params.Context == nil {
@@ -78,17 +159,123 @@ func (c Client) Get(params *Params) *Iter {
 		params.Context = &ctx
 	}
 
-	// Start and End times
+	it := &Iter{}
+	s := &stitcher{gaps: params.Gaps, events: &events{}}
+	var bodies []*form.Values
+	prepared := false
+	it.Of = iter.Stream(*params.Context, func(context.Context) (interface{}, []*finance.ChartBar, bool, error) {
+
+		if !prepared {
+			var err error
+			if bodies, s.pending, err = c.requests(params); err != nil {
+				return nil, nil, false, err
+			}
+			prepared = true
+		}
+
+		// Each page holds as many windows
+		// as are fetched at once.
+		n := params.Concurrency
+		if n < 1 {
+			n = 1
+		}
+		if n > len(bodies) {
+			n = len(bodies)
+		}
+		results, err := c.fetchAll(params, bodies[:n])
+		if err != nil {
+			return nil, nil, false, err
+		}
+		bodies = bodies[n:]
+
+		// Yahoo answers unavailable ranges with a different one.
+		valid := results[0].Meta.ValidRanges
+		if params.Range != "" && len(valid) > 0 && !contains(valid, string(params.Range)) {
+			return nil, nil, false, finance.CreateArgumentErrorS(fmt.Sprintf("range %s is not available for %s, valid ranges are %s",
+				params.Range, params.Symbol, strings.Join(valid, ", ")))
+		}
+
+		bars, err := s.add(results)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		it.dividends, it.splits, it.capitalGains = s.events.parse()
+		return s.meta, bars, len(bodies) > 0, nil
+	})
+	return it
+}
+
+// requests builds the request bodies of params,
+// one per window of the requested span. Dates read in the
+// exchange timezone are returned unresolved, as the timezone
+// is only known once the first response arrives.
+func (c Client) requests(params *Params) ([]*form.Values, *unresolved, error) {
+
+	// Validate interval and range.
+	if err := validate(params); err != nil {
+		return nil, nil, err
+	}
+
+	// Start and End times, which yahoo ignores when a range
+	// is sent. Dates cover whole trading days, read in the
+	// exchange timezone unless they carry their own.
 	params.start = -1
 	params.end = -1
-	if params.Start != nil {
-		params.start = params.Start.Unix()
+	if params.Range != "" {
+		params.start = 0
+		params.end = 0
+		params.rng = string(params.Range)
+	}
+	start, end := params.Start, params.End
+	var pending *unresolved
+	if needsLocation(start) || needsLocation(end) {
+		loc, err := location(params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if loc == nil {
+			pending = &unresolved{start: start, end: end}
+			loc = time.UTC
+		}
+		if needsLocation(start) {
+			start = start.In(loc)
+		}
+		if needsLocation(end) {
+			end = end.In(loc)
+		}
+	}
+	if start != nil {
+		params.start = int(start.SessionStart().Unix())
 	}
-	if params.End != nil {
-		params.end = params.End.Unix()
+	if end != nil {
+		params.end = int(end.SessionEnd().Unix())
+	}
+	if cal := params.Calendar; cal != nil && start != nil && end != nil && start.IsDate() && end.IsDate() {
+		sessions := cal.SessionsBetween(noon(start, cal.Location), noon(end, cal.Location))
+		if len(sessions) == 0 {
+			return nil, nil, finance.CreateArgumentErrorS(
+				fmt.Sprintf("no %s sessions between %d-%02d-%02d and %d-%02d-%02d", cal.Name,
+					start.Year, start.Month, start.Day, end.Year, end.Month, end.Day))
+		}
+		first, last := sessions[0], sessions[len(sessions)-1]
+		params.start, params.end = int(first.Open.Unix()), int(last.Close.Unix())
+		if params.IncludeExt {
+			params.start, params.end = int(first.PreOpen.Unix()), int(last.PostClose.Unix())
+		}
 	}
 	if params.start > params.end {
-		return &Iter{iter.NewE(finance.CreateChartTimeError())}
+		return nil, nil, finance.CreateChartTimeError(params.start, params.end)
+	}
+
+	// Request the widest span the dates can cover in any
+	// timezone, to be trimmed once the exchange timezone is known.
+	if pending != nil {
+		if needsLocation(params.Start) {
+			params.start -= maxZoneOffset
+		}
+		if needsLocation(params.End) {
+			params.end += maxZoneOffset
+		}
 	}
 
 	// Parse interval.
@@ -96,61 +283,338 @@ func (c Client) Get(params *Params) *Iter {
 		params.interval = string(params.Interval)
 	}
 
-	// Build request.
-	body := &form.Values{}
-	form.AppendTo(body, params)
-	// Set request meta data.
-	body.Set("region", "US")
-	body.Set("corsDomain", "com.finance.yahoo")
+	// Parse events.
+	if len(params.Events) > 0 {
+		events := make([]string, len(params.Events))
+		for i, e := range params.Events {
+			events[i] = string(e)
+		}
+		params.events = strings.Join(events, ",")
+	}
 
-	return &Iter{iter.New(body, func(b *form.Values) (m interface{}, bars []interface{}, err error) {
+	// Build one request per window.
+	ws := windows(params.Interval, params.start, params.end)
+	bodies := make([]*form.Values, len(ws))
+	for i, w := range ws {
+		params.start, params.end = w.start, w.end
+		body := &form.Values{}
+		form.AppendTo(body, params)
+		// Set request meta data.
+		body.Set("region", "US")
+		body.Set("corsDomain", "com.finance.yahoo")
+		bodies[i] = body
+	}
+	return bodies, pending, nil
 
-		resp := response{}
-		err = c.B.Call("v8/finance/chart/"+params.Symbol, body, params.Context, &resp)
-		if err != nil {
-			return
-		}
+}
 
-		if resp.Inner.Error != nil {
-			err = resp.Inner.Error
-			return
-		}
+// stitcher joins the windows of a chart in order.
+type stitcher struct {
+	gaps   GapPolicy
+	meta   finance.ChartMeta
+	events *events
+	// started is set once a window has been added.
+	started bool
+	// latest is the timestamp of the latest bar,
+	// valid the latest bar with prices.
+	latest int
+	valid  *finance.ChartBar
+	// pending holds dates to be read in the exchange timezone of the
+	// first window. Bars and events outside them are then dropped,
+	// and before is the latest bar dropped before them.
+	pending  *unresolved
+	from, to int
+	trim     bool
+	before   *finance.ChartBar
+}
 
-		result := resp.Inner.Results[0]
-		if result == nil || result.Indicators == nil {
-			err = finance.CreateRemoteErrorS("no results in chart response")
-			return
+// add returns the bars of results that follow the bars
+// added before, with gaps handled by the gap policy.
+func (s *stitcher) add(results []*result) (bars []*finance.ChartBar, err error) {
+	for _, result := range results {
+		if s.pending != nil {
+			if s.from, s.to, err = s.pending.resolve(result.Meta); err != nil {
+				return nil, err
+			}
+			s.pending, s.trim = nil, true
 		}
 
 		barQuotes := result.Indicators.Quote
-		if barQuotes == nil || barQuotes[0] == nil {
-			err = finance.CreateRemoteErrorS("no results in chart response")
-			return
-		}
 		adjCloses := result.Indicators.Adjclose
 
-		// Process chart response
-		// and chart meta data.
+		var window []*finance.ChartBar
 		for i, t := range result.Timestamp {
+			b := newBar(t, barQuotes[0], i)
+			if b.Valid && len(adjCloses) > 0 && adjCloses[0] != nil {
+				if v, ok := at(adjCloses[0].Adjclose, i); ok {
+					b.AdjClose = decimal.NewFromFloat(v)
+				}
+			}
+			window = append(window, b)
+		}
+		sort.SliceStable(window, func(i, j int) bool { return window[i].Timestamp < window[j].Timestamp })
 
-			b := &finance.ChartBar{
-				Timestamp: t,
-				Open:      decimal.NewFromFloat(barQuotes[0].Open[i]),
-				High:      decimal.NewFromFloat(barQuotes[0].High[i]),
-				Low:       decimal.NewFromFloat(barQuotes[0].Low[i]),
-				Close:     decimal.NewFromFloat(barQuotes[0].Close[i]),
-				Volume:    barQuotes[0].Volume[i],
+		for _, b := range window {
+			// Windows overlap at their bounds.
+			if s.started && b.Timestamp <= s.latest {
+				continue
 			}
+			s.latest = b.Timestamp
 
-			if adjCloses != nil && adjCloses[0] != nil {
-				b.AdjClose = decimal.NewFromFloat(adjCloses[0].Adjclose[i])
+			if s.trim && (b.Timestamp < s.from || b.Timestamp > s.to) {
+				if b.Timestamp < s.from {
+					s.before = b
+					if b.Valid {
+						s.valid = b
+					}
+				}
+				continue
+			}
+
+			if !b.Valid {
+				switch s.gaps {
+				case DropGaps:
+					continue
+				case FillGaps:
+					if s.valid == nil {
+						continue
+					}
+					b.Open = s.valid.Close
+					b.High = s.valid.Close
+					b.Low = s.valid.Close
+					b.Close = s.valid.Close
+					b.AdjClose = s.valid.AdjClose
+					b.Volume = 0
+				}
+			} else {
+				s.valid = b
 			}
 
 			bars = append(bars, b)
 		}
 
-		return result.Meta, bars, nil
-	})}
+		// The latest window describes the current state of the symbol,
+		// the first one the close preceding the whole range.
+		previous := s.meta.ChartPreviousClose
+		s.meta = result.Meta
+		if s.started {
+			s.meta.ChartPreviousClose = previous
+		}
+		if s.trim && s.before != nil && s.before.Valid {
+			s.meta.ChartPreviousClose, _ = s.before.Close.Float64()
+		}
+		s.started = true
+		s.events.merge(result.Events)
+		if s.trim {
+			s.events.trim(s.from, s.to)
+		}
+	}
+	return bars, nil
+}
+
+// validate checks the interval and range of params.
+func validate(params *Params) error {
+	if params.Interval != "" && !params.Interval.Valid() {
+		return finance.CreateArgumentErrorS(fmt.Sprintf("unknown interval %s, ranges belong in Params.Range", params.Interval))
+	}
+	if params.Range == "" {
+		return nil
+	}
+	if params.Start != nil || params.End != nil {
+		return finance.CreateArgumentErrorS("range cannot be combined with start or end")
+	}
+	if !params.Range.Valid() {
+		return finance.CreateArgumentErrorS(fmt.Sprintf("unknown range %s", params.Range))
+	}
+	if params.Interval != "" && !params.Interval.Supports(params.Range) {
+		return finance.CreateArgumentErrorS(fmt.Sprintf("interval %s is not available over range %s", params.Interval, params.Range))
+	}
+	return nil
+}
+
+// noon returns midday of the date of d in loc.
+func noon(d *datetime.Datetime, loc *time.Location) time.Time {
+	return time.Date(d.Year, time.Month(d.Month), d.Day, 12, 0, 0, 0, loc)
+}
+
+// maxZoneOffset is the largest offset from UTC of any timezone,
+// in seconds.
+const maxZoneOffset = 14 * 60 * 60
+
+// needsLocation reports whether d is a date
+// that does not say which timezone it is read in.
+func needsLocation(d *datetime.Datetime) bool {
+	return d != nil && d.IsDate() && d.Location == nil
+}
+
+// location returns the timezone of params.Timezone or params.Calendar,
+// or nil if dates are read in the exchange timezone of params.Symbol.
+func location(params *Params) (*time.Location, error) {
+	if params.Timezone != "" {
+		loc, err := time.LoadLocation(params.Timezone)
+		if err != nil {
+			return nil, finance.CreateArgumentErrorS(fmt.Sprintf("unknown timezone %s", params.Timezone))
+		}
+		return loc, nil
+	}
+	if params.Calendar != nil {
+		return params.Calendar.Location, nil
+	}
+	return nil, nil
+}
+
+// unresolved are the Start and End of a chart
+// read in the exchange timezone of the symbol.
+type unresolved struct {
+	start, end *datetime.Datetime
+}
+
+// resolve returns the span of u, in unix seconds, read in
+// the exchange timezone reported by meta. A nil bound is open.
+func (u *unresolved) resolve(meta finance.ChartMeta) (from, to int, err error) {
+	loc, err := meta.Location()
+	if err != nil {
+		return 0, 0, finance.CreateRemoteError(err)
+	}
+	from, to = math.MinInt, math.MaxInt
+	if start := u.start; start != nil {
+		if needsLocation(start) {
+			start = start.In(loc)
+		}
+		from = int(start.SessionStart().Unix())
+	}
+	if end := u.end; end != nil {
+		if needsLocation(end) {
+			end = end.In(loc)
+		}
+		to = int(end.SessionEnd().Unix())
+	}
+	return from, to, nil
+}
+
+// contains reports whether s is one of values.
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch requests a single chart.
+func (c Client) fetch(params *Params, ctx *context.Context, body *form.Values) (*result, error) {
+	resp := response{}
+	err := c.B.Call("v8/finance/chart/"+params.Symbol, body, ctx, &resp)
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	if resp.Inner.Error != nil {
+		return nil, resp.Inner.Error
+	}
+
+	if len(resp.Inner.Results) == 0 || resp.Inner.Results[0] == nil {
+		return nil, finance.CreateNotFoundError(params.Symbol)
+	}
+
+	result := resp.Inner.Results[0]
+	if result.Indicators == nil {
+		return nil, finance.CreateRemoteErrorS("no results in chart response")
+	}
+
+	barQuotes := result.Indicators.Quote
+	if len(barQuotes) == 0 || barQuotes[0] == nil {
+		return nil, finance.CreateRemoteErrorS("no results in chart response")
+	}
+	return result, nil
+}
+
+// Dividends returns the dividends paid by a symbol
+// between start and end.
+func Dividends(symbol string, start, end *datetime.Datetime) ([]*finance.Dividend, error) {
+	return getC().Dividends(symbol, start, end)
+}
+
+// Dividends returns the dividends paid by a symbol
+// between start and end.
+func (c Client) Dividends(symbol string, start, end *datetime.Datetime) ([]*finance.Dividend, error) {
+	it := c.events(symbol, start, end, DividendEvents)
+	return it.dividends, it.Err()
+}
+
+// Splits returns the stock splits of a symbol
+// between start and end.
+func Splits(symbol string, start, end *datetime.Datetime) ([]*finance.Split, error) {
+	return getC().Splits(symbol, start, end)
+}
+
+// Splits returns the stock splits of a symbol
+// between start and end.
+func (c Client) Splits(symbol string, start, end *datetime.Datetime) ([]*finance.Split, error) {
+	it := c.events(symbol, start, end, SplitEvents)
+	return it.splits, it.Err()
+}
+
+// CapitalGains returns the capital gains distributed
+// by a symbol between start and end.
+func CapitalGains(symbol string, start, end *datetime.Datetime) ([]*finance.CapitalGain, error) {
+	return getC().CapitalGains(symbol, start, end)
+}
+
+// CapitalGains returns the capital gains distributed
+// by a symbol between start and end.
+func (c Client) CapitalGains(symbol string, start, end *datetime.Datetime) ([]*finance.CapitalGain, error) {
+	it := c.events(symbol, start, end, CapitalGainEvents)
+	return it.capitalGains, it.Err()
+}
+
+// events requests a daily chart carrying a single kind of event
+// and reads it to the end.
+func (c Client) events(symbol string, start, end *datetime.Datetime, e Event) *Iter {
+	it := c.Get(&Params{
+		Symbol:   symbol,
+		Start:    start,
+		End:      end,
+		Interval: datetime.OneDay,
+		Events:   []Event{e},
+	})
+	for it.Next() {
+	}
+	return it
+}
+
+// newBar builds the bar at index i of q. A null price leaves the
+// bar invalid with zero prices, a null volume is read as zero.
+func newBar(t int, q *quote, i int) *finance.ChartBar {
+	b := &finance.ChartBar{Timestamp: t}
+	if i < len(q.Volume) && q.Volume[i] != nil {
+		b.Volume = *q.Volume[i]
+	}
+
+	o, okO := at(q.Open, i)
+	h, okH := at(q.High, i)
+	l, okL := at(q.Low, i)
+	c, okC := at(q.Close, i)
+	if !okO || !okH || !okL || !okC {
+		return b
+	}
+
+	b.Open = decimal.NewFromFloat(o)
+	b.High = decimal.NewFromFloat(h)
+	b.Low = decimal.NewFromFloat(l)
+	b.Close = decimal.NewFromFloat(c)
+	b.Valid = true
+	return b
+}
+
+// at returns the value at index i of vals,
+// or false if it is null or missing.
+func at(vals []*float64, i int) (float64, bool) {
+	if i >= len(vals) || vals[i] == nil {
+		return 0, false
+	}
+	return *vals[i], true
 }
 
 // response is a yfin chart response.
@@ -165,16 +629,120 @@ type response struct {
 type result struct {
 	Meta       finance.ChartMeta `json:"meta"`
 	Timestamp  []int             `json:"timestamp"`
-	Indicators *struct {
-		Quote []*struct {
-			Open   []float64 `json:"open"`
-			Low    []float64 `json:"low"`
-			High   []float64 `json:"high"`
-			Close  []float64 `json:"close"`
-			Volume []int     `json:"volume"`
-		} `json:"quote"`
-		Adjclose []*struct {
-			Adjclose []float64 `json:"adjclose"`
-		} `json:"adjclose"`
-	} `json:"indicators"`
+	Indicators *indicators       `json:"indicators"`
+	Events     *events           `json:"events"`
+}
+
+// indicators holds the bar values of a chart result.
+type indicators struct {
+	Quote    []*quote `json:"quote"`
+	Adjclose []*struct {
+		Adjclose []*float64 `json:"adjclose"`
+	} `json:"adjclose"`
+}
+
+// events holds the corporate actions of a chart result,
+// keyed by their timestamp.
+type events struct {
+	Dividends map[string]*struct {
+		Amount float64 `json:"amount"`
+		Date   int     `json:"date"`
+	} `json:"dividends"`
+	Splits map[string]*struct {
+		Numerator   float64 `json:"numerator"`
+		Denominator float64 `json:"denominator"`
+		SplitRatio  string  `json:"splitRatio"`
+		Date        int     `json:"date"`
+	} `json:"splits"`
+	CapitalGains map[string]*struct {
+		Amount float64 `json:"amount"`
+		Date   int     `json:"date"`
+	} `json:"capitalGains"`
+}
+
+// trim drops the events outside [from, to].
+func (e *events) trim(from, to int) {
+	for k, d := range e.Dividends {
+		if d == nil || d.Date < from || d.Date > to {
+			delete(e.Dividends, k)
+		}
+	}
+	for k, sp := range e.Splits {
+		if sp == nil || sp.Date < from || sp.Date > to {
+			delete(e.Splits, k)
+		}
+	}
+	for k, g := range e.CapitalGains {
+		if g == nil || g.Date < from || g.Date > to {
+			delete(e.CapitalGains, k)
+		}
+	}
+}
+
+// merge adds the events of other to e.
+func (e *events) merge(other *events) {
+	if other == nil {
+		return
+	}
+	if e.Dividends == nil {
+		e.Dividends = other.Dividends
+	} else {
+		for k, v := range other.Dividends {
+			e.Dividends[k] = v
+		}
+	}
+	if e.Splits == nil {
+		e.Splits = other.Splits
+	} else {
+		for k, v := range other.Splits {
+			e.Splits[k] = v
+		}
+	}
+	if e.CapitalGains == nil {
+		e.CapitalGains = other.CapitalGains
+	} else {
+		for k, v := range other.CapitalGains {
+			e.CapitalGains[k] = v
+		}
+	}
+}
+
+// parse returns the events sorted by date.
+func (e *events) parse() (divs []*finance.Dividend, splits []*finance.Split, gains []*finance.CapitalGain) {
+	for _, d := range e.Dividends {
+		divs = append(divs, &finance.Dividend{
+			Amount: decimal.NewFromFloat(d.Amount),
+			Date:   d.Date,
+		})
+	}
+	sort.Slice(divs, func(i, j int) bool { return divs[i].Date < divs[j].Date })
+
+	for _, s := range e.Splits {
+		splits = append(splits, &finance.Split{
+			Numerator:   s.Numerator,
+			Denominator: s.Denominator,
+			Ratio:       s.SplitRatio,
+			Date:        s.Date,
+		})
+	}
+	sort.Slice(splits, func(i, j int) bool { return splits[i].Date < splits[j].Date })
+
+	for _, g := range e.CapitalGains {
+		gains = append(gains, &finance.CapitalGain{
+			Amount: decimal.NewFromFloat(g.Amount),
+			Date:   g.Date,
+		})
+	}
+	sort.Slice(gains, func(i, j int) bool { return gains[i].Date < gains[j].Date })
+	return
+}
+
+// quote holds the bar values of a chart result.
+// Yahoo reports missing values as null.
+type quote struct {
+	Open   []*float64 `json:"open"`
+	Low    []*float64 `json:"low"`
+	High   []*float64 `json:"high"`
+	Close  []*float64 `json:"close"`
+	Volume []*int     `json:"volume"`
 }
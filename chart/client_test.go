@@ -1,8 +1,18 @@
 package chart
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/calendar"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/form"
 	tests "github.com/piquette/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -54,4 +64,262 @@ func TestBadSymbolChart(t *testing.T) {
 	chart := Get(p)
 	assert.False(t, chart.Next())
 	assert.NotNil(t, chart.Err())
+	assert.True(t, errors.Is(chart.Err(), finance.ErrNotFound))
+}
+
+// fixtureBackend answers every call with a file from testdata
+// and keeps the last request body.
+type fixtureBackend struct {
+	file  string
+	body  *form.Values
+	calls int
+}
+
+func (f *fixtureBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	f.body = body
+	f.calls++
+	data, err := os.ReadFile(filepath.Join("testdata", f.file))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func collect(t *testing.T, i *Iter) []*finance.ChartBar {
+	var bars []*finance.ChartBar
+	for i.Next() {
+		bars = append(bars, i.Bar())
+	}
+	assert.Nil(t, i.Err())
+	return bars
+}
+
+func TestIntradayGaps(t *testing.T) {
+	c := Client{B: &fixtureBackend{file: "intraday_gaps.json"}}
+
+	bars := collect(t, c.Get(&Params{Symbol: "AAPL"}))
+	assert.Len(t, bars, 6)
+	assert.False(t, bars[0].Valid)
+	assert.True(t, bars[0].Close.IsZero())
+	assert.True(t, bars[1].Valid)
+	assert.Equal(t, "187.9", bars[1].Close.String())
+	assert.Equal(t, 412030, bars[1].Volume)
+	assert.False(t, bars[4].Valid)
+
+	bars = collect(t, c.Get(&Params{Symbol: "AAPL", Gaps: DropGaps}))
+	assert.Len(t, bars, 3)
+	for _, b := range bars {
+		assert.True(t, b.Valid)
+	}
+
+	bars = collect(t, c.Get(&Params{Symbol: "AAPL", Gaps: FillGaps}))
+	assert.Len(t, bars, 5)
+	assert.Equal(t, 1515681300, bars[0].Timestamp)
+	for _, b := range bars[2:4] {
+		assert.False(t, b.Valid)
+		assert.Equal(t, "188.02", b.Open.String())
+		assert.Equal(t, "188.02", b.Close.String())
+		assert.Equal(t, 0, b.Volume)
+	}
+	assert.True(t, bars[4].Valid)
+}
+
+func TestDailyGaps(t *testing.T) {
+	c := Client{B: &fixtureBackend{file: "daily_gaps.json"}}
+
+	bars := collect(t, c.Get(&Params{Symbol: "AAPL", Gaps: FillGaps}))
+	assert.Len(t, bars, 4)
+	assert.False(t, bars[2].Valid)
+	assert.Equal(t, "175.28", bars[2].Close.String())
+	assert.Equal(t, "168.46", bars[2].AdjClose.String())
+
+	bars = collect(t, c.Get(&Params{Symbol: "AAPL", Gaps: DropGaps}))
+	assert.Len(t, bars, 3)
+	assert.Equal(t, 1516113000, bars[2].Timestamp)
+}
+
+func TestPartiallyNullBar(t *testing.T) {
+	c := Client{B: &fixtureBackend{file: "partial_nulls.json"}}
+
+	bars := collect(t, c.Get(&Params{Symbol: "AAPL"}))
+	assert.Len(t, bars, 2)
+	assert.True(t, bars[0].Valid)
+	assert.False(t, bars[1].Valid)
+	assert.True(t, bars[1].Open.IsZero())
+	assert.True(t, bars[1].High.IsZero())
+	assert.True(t, bars[1].Low.IsZero())
+	assert.True(t, bars[1].Close.IsZero())
+	assert.True(t, bars[1].AdjClose.IsZero())
+	assert.Equal(t, 21584000, bars[1].Volume)
+}
+
+func TestNullChartValues(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("nulls are only injected by the in-process server")
+	}
+	tests.DefaultServer.SetFault(tests.Fault{NullArrays: true})
+	defer tests.DefaultServer.ClearFault()
+
+	bars := collect(t, Get(&Params{Symbol: tests.TestEquitySymbol}))
+	assert.True(t, len(bars) > 2)
+	assert.True(t, bars[0].Valid)
+	assert.False(t, bars[1].Valid)
+}
+
+func TestChartEvents(t *testing.T) {
+	b := &fixtureBackend{file: "events.json"}
+	c := Client{B: b}
+
+	i := c.Get(&Params{Symbol: "AAPL", Events: []Event{DividendEvents, SplitEvents}})
+	assert.Len(t, collect(t, i), 2)
+	assert.Equal(t, "div,splits", b.body.Get("events")[0])
+
+	divs := i.Dividends()
+	assert.Len(t, divs, 4)
+	assert.Equal(t, 1573137000, divs[0].Date)
+	assert.Equal(t, "0.1925", divs[0].Amount.String())
+	assert.Equal(t, 1596807000, divs[3].Date)
+
+	splits := i.Splits()
+	assert.Len(t, splits, 1)
+	assert.Equal(t, 4.0, splits[0].Numerator)
+	assert.Equal(t, 1.0, splits[0].Denominator)
+	assert.Equal(t, "4:1", splits[0].Ratio)
+	assert.Empty(t, i.CapitalGains())
+}
+
+func TestEventHelpers(t *testing.T) {
+	b := &fixtureBackend{file: "events.json"}
+	c := Client{B: b}
+
+	divs, err := c.Dividends("AAPL", nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, divs, 4)
+	assert.Equal(t, "div", b.body.Get("events")[0])
+	assert.Equal(t, "1d", b.body.Get("interval")[0])
+
+	splits, err := c.Splits("AAPL", nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, splits, 1)
+
+	c.B = &fixtureBackend{file: "fund_events.json"}
+	gains, err := c.CapitalGains("AGTHX", nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, gains, 2)
+	assert.Equal(t, "2.574", gains[0].Amount.String())
+
+	_, err = c.Dividends("", nil, nil)
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+}
+
+func TestChartRange(t *testing.T) {
+	b := &fixtureBackend{file: "events.json"}
+	c := Client{B: b}
+
+	i := c.Get(&Params{Symbol: "AAPL", Range: datetime.FiveYearRange, Interval: datetime.OneWeek})
+	assert.Len(t, collect(t, i), 2)
+	assert.Equal(t, []string{"5y"}, b.body.Get("range"))
+	assert.Equal(t, []string{"1wk"}, b.body.Get("interval"))
+	assert.Empty(t, b.body.Get("period1"))
+	assert.Empty(t, b.body.Get("period2"))
+
+	c.B = &fixtureBackend{file: "fund_events.json"}
+	i = c.Get(&Params{Symbol: "AGTHX", Range: datetime.FiveDayRange})
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
+
+func TestChartRangeArguments(t *testing.T) {
+	start := datetime.FromUnix(1515594600)
+	for _, p := range []*Params{
+		{Symbol: "AAPL", Range: datetime.YTDRange, Start: start},
+		{Symbol: "AAPL", Range: datetime.Range("7d")},
+		{Symbol: "AAPL", Range: datetime.OneYearRange, Interval: datetime.OneMin},
+		{Symbol: "AAPL", Interval: datetime.YTD},
+	} {
+		b := &fixtureBackend{file: "events.json"}
+		i := Client{B: b}.Get(p)
+		assert.False(t, i.Next())
+		var aerr *finance.ArgumentError
+		assert.True(t, errors.As(i.Err(), &aerr))
+		assert.Nil(t, b.body)
+	}
+}
+
+func TestChartDatesInExchangeTimezone(t *testing.T) {
+	b := &fixtureBackend{file: "daily_gaps.json"}
+	c := Client{B: b}
+
+	// The span covering the date in any timezone is requested
+	// at once, and trimmed to the date in New York.
+	day := datetime.Date(2018, time.January, 11, nil)
+	i := c.Get(&Params{Symbol: "AAPL", Start: day, End: day})
+	bars := collect(t, i)
+	assert.Len(t, bars, 1)
+	assert.Equal(t, 1515681000, bars[0].Timestamp)
+	assert.Equal(t, 174.29, i.Meta().ChartPreviousClose)
+	assert.Equal(t, 1, b.calls)
+	assert.Equal(t, []string{"1515578400"}, b.body.Get("period1"))
+	assert.Equal(t, []string{"1515765599"}, b.body.Get("period2"))
+
+	assert.Len(t, collect(t, c.Get(&Params{Symbol: "AAPL", Start: day, End: day, Timezone: "Europe/London"})), 4)
+	assert.Equal(t, []string{"1515628800"}, b.body.Get("period1"))
+	assert.Equal(t, []string{"1515715199"}, b.body.Get("period2"))
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	start := datetime.Date(2018, time.January, 11, tokyo)
+	assert.Len(t, collect(t, c.Get(&Params{Symbol: "AAPL", Start: start, End: day})), 1)
+	assert.Equal(t, []string{"1515596400"}, b.body.Get("period1"))
+	assert.Equal(t, []string{"1515765599"}, b.body.Get("period2"))
+
+	// A gap opening the range is filled from the bar before it.
+	bars = collect(t, c.Get(&Params{
+		Symbol: "AAPL",
+		Start:  datetime.Date(2018, time.January, 12, nil),
+		End:    datetime.Date(2018, time.January, 16, nil),
+		Gaps:   FillGaps,
+	}))
+	assert.Len(t, bars, 2)
+	assert.False(t, bars[0].Valid)
+	assert.Equal(t, 1515767400, bars[0].Timestamp)
+	assert.Equal(t, "175.28", bars[0].Close.String())
+	assert.Equal(t, "168.46", bars[0].AdjClose.String())
+	assert.True(t, bars[1].Valid)
+
+	i = c.Get(&Params{Symbol: "AAPL", Start: day, End: day, Timezone: "Mars/Olympus"})
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
+
+func TestChartCalendar(t *testing.T) {
+	b := &fixtureBackend{file: "daily_gaps.json"}
+	c := Client{B: b}
+
+	// Jan 15th 2018 is Martin Luther King Jr. Day.
+	p := &Params{
+		Symbol:   "AAPL",
+		Start:    datetime.Date(2018, time.January, 13, nil),
+		End:      datetime.Date(2018, time.January, 16, nil),
+		Calendar: calendar.NYSE,
+	}
+	assert.Len(t, collect(t, c.Get(p)), 4)
+	assert.Equal(t, []string{"1516113000"}, b.body.Get("period1"))
+	assert.Equal(t, []string{"1516136400"}, b.body.Get("period2"))
+
+	p.End = datetime.Date(2018, time.January, 15, nil)
+	i := c.Get(p)
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
+
+func TestChartStartAfterEnd(t *testing.T) {
+	b := &fixtureBackend{file: "daily_gaps.json"}
+	i := Client{B: b}.Get(&Params{Symbol: "AAPL", Start: datetime.FromUnix(1515681000), End: datetime.FromUnix(1515594600)})
+	assert.False(t, i.Next())
+
+	var cte *finance.ChartTimeError
+	assert.True(t, errors.As(i.Err(), &cte))
+	assert.Equal(t, 1515681000, cte.Start)
+	assert.Equal(t, 1515594600, cte.End)
+	assert.Nil(t, b.body)
 }
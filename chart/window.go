@@ -0,0 +1,102 @@
+package chart
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	form "github.com/piquette/finance-go/form"
+)
+
+// window is a span of unix seconds requested at once.
+type window struct {
+	start int
+	end   int
+}
+
+// maxSpan returns the longest span yahoo serves in a single
+// request at the given interval, or 0 if it is not limited.
+func maxSpan(interval datetime.Interval) int {
+	return interval.MaxDays() * int((24 * time.Hour).Seconds())
+}
+
+// windows splits the span from start to end into windows yahoo
+// serves at the given interval. Open spans are never split.
+func windows(interval datetime.Interval, start, end int) []window {
+	span := maxSpan(interval)
+	if span == 0 || start < 0 || end < 0 || end-start <= span {
+		return []window{{start, end}}
+	}
+
+	var ws []window
+	for s := start; s < end; s += span {
+		e := s + span
+		if e > end {
+			e = end
+		}
+		ws = append(ws, window{s, e})
+	}
+	return ws
+}
+
+// fetchAll requests every body, up to params.Concurrency at once,
+// and returns the results in the order of bodies. The first error
+// cancels the outstanding requests and is returned.
+func (c Client) fetchAll(params *Params, bodies []*form.Values) ([]*result, error) {
+	if len(bodies) == 1 {
+		r, err := c.fetch(params, params.Context, bodies[0])
+		if err != nil {
+			return nil, err
+		}
+		return []*result{r}, nil
+	}
+
+	ctx, cancel := context.WithCancel(*params.Context)
+	defer cancel()
+
+	limit := params.Concurrency
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+
+	results := make([]*result, len(bodies))
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		failure error
+	)
+	for i, body := range bodies {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int, body *form.Values) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r, err := c.fetch(params, &ctx, body)
+			if err != nil {
+				once.Do(func() {
+					failure = err
+					cancel()
+				})
+				return
+			}
+			results[i] = r
+		}(i, body)
+	}
+	wg.Wait()
+
+	if failure == nil && ctx.Err() != nil {
+		// The caller gave up before every window was requested.
+		failure = finance.CreateRemoteError(ctx.Err())
+	}
+	if failure != nil {
+		return nil, failure
+	}
+	return results, nil
+}
@@ -0,0 +1,168 @@
+package chart
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/assert"
+)
+
+const day = 24 * 60 * 60
+
+// hourlyBackend answers chart requests with one bar per hour
+// between period1 and period2, both included.
+type hourlyBackend struct {
+	mu       sync.Mutex
+	windows  [][2]int
+	inflight int32
+	peak     int32
+	fail     int
+}
+
+func (h *hourlyBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	n := atomic.AddInt32(&h.inflight, 1)
+	defer atomic.AddInt32(&h.inflight, -1)
+	for {
+		p := atomic.LoadInt32(&h.peak)
+		if n <= p || atomic.CompareAndSwapInt32(&h.peak, p, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	start, _ := strconv.Atoi(body.Get("period1")[0])
+	end, _ := strconv.Atoi(body.Get("period2")[0])
+	h.mu.Lock()
+	h.windows = append(h.windows, [2]int{start, end})
+	h.mu.Unlock()
+	if start == h.fail {
+		return errors.New("window unavailable")
+	}
+
+	r := &result{Meta: finance.ChartMeta{Symbol: "AAPL", ChartPreviousClose: float64(start)}}
+	r.Indicators = &indicators{Quote: []*quote{{}}}
+	q := r.Indicators.Quote[0]
+	for t := start; t <= end; t += 3600 {
+		price := float64(t)
+		volume := 1
+		r.Timestamp = append(r.Timestamp, t)
+		q.Open = append(q.Open, &price)
+		q.High = append(q.High, &price)
+		q.Low = append(q.Low, &price)
+		q.Close = append(q.Close, &price)
+		q.Volume = append(q.Volume, &volume)
+	}
+
+	resp := v.(*response)
+	resp.Inner.Results = []*result{r}
+	return nil
+}
+
+func TestWindows(t *testing.T) {
+	assert.Len(t, windows(datetime.OneDay, 0, 3650*day), 1)
+	assert.Len(t, windows(datetime.OneMin, -1, -1), 1)
+	assert.Len(t, windows(datetime.OneMin, 0, 7*day), 1)
+
+	ws := windows(datetime.OneMin, 0, 20*day)
+	assert.Equal(t, []window{{0, 7 * day}, {7 * day, 14 * day}, {14 * day, 20 * day}}, ws)
+
+	ws = windows(datetime.FiveMins, 0, 150*day)
+	assert.Equal(t, []window{{0, 60 * day}, {60 * day, 120 * day}, {120 * day, 150 * day}}, ws)
+
+	assert.Len(t, windows(datetime.OneHour, 0, 730*day), 1)
+	ws = windows(datetime.SixtyMins, 0, 1000*day)
+	assert.Equal(t, []window{{0, 730 * day}, {730 * day, 1000 * day}}, ws)
+}
+
+func TestChunkedChart(t *testing.T) {
+	start := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 20)
+
+	for _, concurrency := range []int{0, 3} {
+		b := &hourlyBackend{fail: -1}
+		i := Client{B: b}.Get(&Params{
+			Symbol:      "AAPL",
+			Interval:    datetime.OneMin,
+			Start:       datetime.New(&start),
+			End:         datetime.New(&end),
+			Concurrency: concurrency,
+		})
+		bars := collect(t, i)
+
+		assert.Len(t, b.windows, 3)
+		assert.Len(t, bars, 20*24+1)
+		for n, bar := range bars {
+			assert.Equal(t, int(start.Unix())+n*3600, bar.Timestamp)
+		}
+		assert.Equal(t, "AAPL", i.Meta().Symbol)
+		assert.Equal(t, float64(start.Unix()), i.Meta().ChartPreviousClose)
+		if concurrency == 0 {
+			assert.Equal(t, int32(1), b.peak)
+		} else {
+			assert.True(t, b.peak > 1)
+		}
+	}
+}
+
+func TestChunkedChartError(t *testing.T) {
+	start := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 20)
+	b := &hourlyBackend{fail: int(start.Unix()) + 7*day}
+
+	i := Client{B: b}.Get(&Params{
+		Symbol:   "AAPL",
+		Interval: datetime.OneMin,
+		Start:    datetime.New(&start),
+		End:      datetime.New(&end),
+	})
+
+	// The bars of the first window are streamed before the error.
+	n := 0
+	for i.Next() {
+		n++
+	}
+	assert.Equal(t, 7*24+1, n)
+	assert.True(t, errors.Is(i.Err(), finance.ErrRemote))
+	assert.Len(t, b.windows, 2)
+}
+
+func TestChunkedChartStream(t *testing.T) {
+	start := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 20)
+	b := &hourlyBackend{fail: -1}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &Params{
+		Symbol:   "AAPL",
+		Interval: datetime.OneMin,
+		Start:    datetime.New(&start),
+		End:      datetime.New(&end),
+	}
+	p.Context = &ctx
+	i := Client{B: b}.Get(p)
+	assert.Empty(t, b.windows)
+
+	assert.True(t, i.Next())
+	assert.Len(t, b.windows, 1)
+	assert.Equal(t, 7*24+1, i.Count())
+	assert.Equal(t, 1, i.Consumed())
+
+	// The bars of the first window are still yielded.
+	cancel()
+	n := 1
+	for i.Next() {
+		n++
+	}
+	assert.Equal(t, 7*24+1, n)
+	assert.True(t, errors.Is(i.Err(), context.Canceled))
+	assert.Len(t, b.windows, 1)
+}
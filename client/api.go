@@ -0,0 +1,97 @@
+// Package client provides a set of API clients that share
+// one independently configured backend.
+package client
+
+import (
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/analysis"
+	"github.com/piquette/finance-go/chart"
+	"github.com/piquette/finance-go/crypto"
+	"github.com/piquette/finance-go/equity"
+	"github.com/piquette/finance-go/etf"
+	"github.com/piquette/finance-go/events"
+	"github.com/piquette/finance-go/forex"
+	"github.com/piquette/finance-go/fundamentals"
+	"github.com/piquette/finance-go/future"
+	"github.com/piquette/finance-go/holders"
+	"github.com/piquette/finance-go/index"
+	"github.com/piquette/finance-go/mutualfund"
+	"github.com/piquette/finance-go/option"
+	"github.com/piquette/finance-go/options"
+	"github.com/piquette/finance-go/quote"
+	"github.com/piquette/finance-go/screener"
+	"github.com/piquette/finance-go/summary"
+	"github.com/piquette/finance-go/symbols"
+)
+
+// API is the finance client. It contains all the different resources available.
+type API struct {
+	// Analysis is the client used to invoke analyst coverage APIs.
+	Analysis *analysis.Client
+	// Chart is the client used to invoke chart APIs.
+	Chart *chart.Client
+	// Crypto is the client used to invoke crypto pair quote APIs.
+	Crypto *crypto.Client
+	// Equity is the client used to invoke equity quote APIs.
+	Equity *equity.Client
+	// ETF is the client used to invoke etf quote APIs.
+	ETF *etf.Client
+	// Events is the client used to invoke corporate event and calendar APIs.
+	Events *events.Client
+	// Forex is the client used to invoke forex pair quote APIs.
+	Forex *forex.Client
+	// Fundamentals is the client used to invoke financial statement APIs.
+	Fundamentals *fundamentals.Client
+	// Future is the client used to invoke futures quote APIs.
+	Future *future.Client
+	// Holders is the client used to invoke ownership APIs.
+	Holders *holders.Client
+	// Index is the client used to invoke index quote APIs.
+	Index *index.Client
+	// MutualFund is the client used to invoke mutual fund quote APIs.
+	MutualFund *mutualfund.Client
+	// Option is the client used to invoke option contract quote APIs.
+	Option *option.Client
+	// Options is the client used to invoke options chain APIs.
+	Options *options.Client
+	// Quote is the client used to invoke quote APIs.
+	Quote *quote.Client
+	// Screener is the client used to invoke screener APIs.
+	Screener *screener.Client
+	// Symbols is the client used to invoke symbol search APIs.
+	Symbols *symbols.Client
+	// Summary is the client used to invoke quoteSummary APIs.
+	Summary *summary.Client
+}
+
+// Init initializes the finance client with the
+// appropriate backend.
+func (a *API) Init(b finance.Backend) {
+	a.Analysis = &analysis.Client{B: b}
+	a.Chart = &chart.Client{B: b}
+	a.Crypto = &crypto.Client{B: b}
+	a.Equity = &equity.Client{B: b}
+	a.ETF = &etf.Client{B: b}
+	a.Events = &events.Client{B: b}
+	a.Forex = &forex.Client{B: b}
+	a.Fundamentals = &fundamentals.Client{B: b}
+	a.Future = &future.Client{B: b}
+	a.Holders = &holders.Client{B: b}
+	a.Index = &index.Client{B: b}
+	a.MutualFund = &mutualfund.Client{B: b}
+	a.Option = &option.Client{B: b}
+	a.Options = &options.Client{B: b}
+	a.Quote = &quote.Client{B: b}
+	a.Screener = &screener.Client{B: b}
+	a.Symbols = &symbols.Client{B: b}
+	a.Summary = &summary.Client{B: b}
+}
+
+// New creates a new finance client with its own backend,
+// HTTP client and logger built from cfg.
+// A nil cfg uses the defaults described on finance.Config.
+func New(cfg *finance.Config) *API {
+	api := API{}
+	api.Init(finance.New(cfg).YFin)
+	return &api
+}
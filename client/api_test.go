@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/piquette/finance-go/chart"
+	"github.com/piquette/finance-go/equity"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/quote"
+	"github.com/stretchr/testify/assert"
+)
+
+// pathBackend records the paths it is called with.
+type pathBackend struct {
+	paths []string
+}
+
+func (b *pathBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	b.paths = append(b.paths, path)
+	return nil
+}
+
+func TestInit(t *testing.T) {
+	b := &pathBackend{}
+	api := API{}
+	api.Init(b)
+
+	api.Quote.ListP(&quote.Params{Symbols: []string{"AAPL"}})
+	api.Equity.ListP(&equity.Params{Symbols: []string{"AAPL"}})
+	api.Chart.Get(&chart.Params{Symbol: "AAPL"}).Next()
+
+	assert.Equal(t, []string{"/v7/finance/quote", "/v7/finance/quote", "v8/finance/chart/AAPL"}, b.paths)
+}
+
+func TestNew(t *testing.T) {
+	first := New(nil)
+	second := New(nil)
+
+	assert.NotNil(t, first.Quote.B)
+	assert.True(t, first.Quote.B == first.Chart.B)
+	assert.True(t, first.Quote.B != second.Quote.B)
+}
@@ -0,0 +1,98 @@
+package finance
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Config describes a self-contained set of backends. Backends created
+// from a Config share nothing with the package-level defaults or with
+// backends created from other Configs, except for the package-level
+// Logger and LogLevel when Logger is nil, so several differently
+// configured instances can be used side by side in one process.
+type Config struct {
+	// HTTPClient is the client used for every request.
+	// If nil, a new client is built from Timeout and Proxy.
+	HTTPClient *http.Client
+	// Timeout is the request timeout of the built HTTP client.
+	// It defaults to 80 seconds and is ignored if HTTPClient is set.
+	Timeout time.Duration
+	// Proxy selects the proxy of the built HTTP client, as in
+	// http.Transport. It is ignored if HTTPClient is set.
+	Proxy func(*http.Request) (*url.URL, error)
+	// URL overrides the base URL of the yahoo backend.
+	URL string
+	// Logger receives log output, filtered by LogLevel.
+	// If nil, the package-level Logger and LogLevel are used.
+	Logger   Printfer
+	LogLevel int
+	// Retry is the retry policy. If nil, NewRetryPolicy is used.
+	// Set MaxAttempts to 1 to disable retries.
+	Retry *RetryPolicy
+	// Sessions provides the crumb and cookies sent to the yahoo backend.
+	// If nil, they are fetched from the yahoo login and crumb endpoints.
+	Sessions SessionProvider
+	// Limiter throttles requests. If nil, a new limiter allowing 10
+	// requests per second with bursts of 20 is used. Use
+	// NewRateLimiter(0, 0) to disable throttling.
+	Limiter *RateLimiter
+}
+
+// New creates a self-contained set of backends from cfg.
+// A nil cfg is equivalent to an empty Config.
+func New(cfg *Config) *Backends {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		timeout := cfg.Timeout
+		if timeout == 0 {
+			timeout = defaultHTTPTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+		if cfg.Proxy != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.Proxy = cfg.Proxy
+			client.Transport = transport
+		}
+	}
+
+	yfinURL := cfg.URL
+	if yfinURL == "" {
+		yfinURL = YFinURL
+	}
+
+	retry := cfg.Retry
+	if retry == nil {
+		retry = NewRetryPolicy()
+	}
+
+	limiter := cfg.Limiter
+	if limiter == nil {
+		limiter = NewRateLimiter(defaultRateLimit, defaultRateBurst)
+	}
+
+	base := BackendConfiguration{
+		HTTPClient: client,
+		Logger:     cfg.Logger,
+		LogLevel:   cfg.LogLevel,
+		Retry:      retry,
+		Limiter:    limiter,
+	}
+
+	yfin := base
+	yfin.Type = YFinBackend
+	yfin.URL = yfinURL
+
+	bats := base
+	bats.Type = BATSBackend
+	bats.URL = BATSURL
+
+	return &Backends{
+		YFin: newYahooConfiguration(yfin, cfg.Sessions),
+		Bats: &bats,
+	}
+}
@@ -0,0 +1,145 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/assert"
+)
+
+// bufLogger collects log output for inspection.
+type bufLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *bufLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func (l *bufLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "")
+}
+
+func TestNewDefaults(t *testing.T) {
+	b := New(nil)
+
+	y, ok := b.YFin.(*yahooConfiguration)
+	assert.True(t, ok)
+	assert.Equal(t, YFinURL, y.URL)
+	assert.Equal(t, defaultHTTPTimeout, y.HTTPClient.Timeout)
+	assert.NotNil(t, y.Retry)
+	assert.NotNil(t, y.Limiter)
+
+	// Instances never share state with each other or the defaults.
+	other := New(nil).YFin.(*yahooConfiguration)
+	assert.True(t, y.HTTPClient != other.HTTPClient)
+	assert.True(t, y.Limiter != other.Limiter)
+	assert.True(t, y.HTTPClient != httpClient)
+}
+
+func TestNewConfig(t *testing.T) {
+	client := &http.Client{}
+	retry := &RetryPolicy{MaxAttempts: 1}
+	limiter := NewRateLimiter(0, 0)
+
+	b := New(&Config{
+		HTTPClient: client,
+		URL:        "http://localhost:12111",
+		Retry:      retry,
+		Limiter:    limiter,
+	})
+
+	y := b.YFin.(*yahooConfiguration)
+	assert.Equal(t, "http://localhost:12111", y.URL)
+	assert.True(t, y.HTTPClient == client)
+	assert.True(t, y.Retry == retry)
+	assert.True(t, y.Limiter == limiter)
+
+	b = New(&Config{Timeout: time.Second, Proxy: http.ProxyFromEnvironment})
+	y = b.YFin.(*yahooConfiguration)
+	assert.Equal(t, time.Second, y.HTTPClient.Timeout)
+	assert.NotNil(t, y.HTTPClient.Transport.(*http.Transport).Proxy)
+}
+
+func TestGetBackendDefaults(t *testing.T) {
+	old := GetBackend(YFinBackend)
+	defer SetBackend(YFinBackend, old)
+	defer SetRetryPolicy(retryPolicy)
+	defer SetRateLimiter(rateLimiter)
+
+	// The default backend is built from the package-level settings.
+	SetRetryPolicy(nil)
+	SetRateLimiter(nil)
+	SetBackend(YFinBackend, nil)
+	y := GetBackend(YFinBackend).(*yahooConfiguration)
+	assert.True(t, y.HTTPClient == httpClient)
+	assert.Equal(t, YFinURL, y.URL)
+	assert.Equal(t, 1, y.Retry.attempts())
+	assert.True(t, GetBackend(YFinBackend) == Backend(y))
+
+	assert.Equal(t, BATSURL, GetBackend(BATSBackend).(*BackendConfiguration).URL)
+	assert.Nil(t, GetBackend("unknown"))
+}
+
+func TestNewIndependentLoggers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	first, second := &bufLogger{}, &bufLogger{}
+	a := New(&Config{URL: srv.URL, Logger: first, LogLevel: 2}).Bats.(*BackendConfiguration)
+	b := New(&Config{URL: srv.URL, Logger: second, LogLevel: 0}).Bats.(*BackendConfiguration)
+	a.URL, b.URL = srv.URL, srv.URL
+
+	assert.Nil(t, a.Call("/first", nil, nil, nil))
+	assert.Nil(t, b.Call("/second", nil, nil, nil))
+
+	assert.True(t, strings.Contains(first.String(), "/first"))
+	assert.False(t, strings.Contains(first.String(), "/second"))
+	assert.Equal(t, "", second.String())
+}
+
+func TestSetBackendConcurrent(t *testing.T) {
+	old := GetBackend(YFinBackend)
+	defer SetBackend(YFinBackend, old)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetBackend(YFinBackend, New(nil).YFin)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotNil(t, GetBackend(YFinBackend))
+		}()
+	}
+	wg.Wait()
+}
+
+// getOnly is a Backend without POST support.
+type getOnly struct{}
+
+func (getOnly) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	return nil
+}
+
+func TestPostUnsupported(t *testing.T) {
+	err := Post(getOnly{}, "/", nil, nil, nil, nil)
+	assert.True(t, errors.Is(err, ErrArgument))
+}
@@ -28,44 +28,161 @@ const (
 	OneDay Interval = "1d"
 	// FiveDay interval of 5 days.
 	FiveDay Interval = "5d"
+	// OneWeek interval of 1 week.
+	OneWeek Interval = "1wk"
 	// OneMonth interval of 1 month.
 	OneMonth Interval = "1mo"
 	// ThreeMonth interval of 3 months.
 	ThreeMonth Interval = "3mo"
-	// SixMonth interval of 6 months.
+	// SixMonth is a range.
+	//
+	// Deprecated: use SixMonthRange with chart.Params.Range.
 	SixMonth Interval = "6mo"
-	// OneYear interval of 1 year.
+	// OneYear is a range.
+	//
+	// Deprecated: use OneYearRange with chart.Params.Range.
 	OneYear Interval = "1y"
-	// TwoYear interval of 2 years.
+	// TwoYear is a range.
+	//
+	// Deprecated: use TwoYearRange with chart.Params.Range.
 	TwoYear Interval = "2y"
-	// FiveYear interval of 5 years.
+	// FiveYear is a range.
+	//
+	// Deprecated: use FiveYearRange with chart.Params.Range.
 	FiveYear Interval = "5y"
-	// TenYear interval of 10 years.
+	// TenYear is a range.
+	//
+	// Deprecated: use TenYearRange with chart.Params.Range.
 	TenYear Interval = "10y"
-	// YTD interval of year-to-date.
+	// YTD is a range.
+	//
+	// Deprecated: use YTDRange with chart.Params.Range.
 	YTD Interval = "ytd"
-	// Max interval.
+	// Max is a range.
+	//
+	// Deprecated: use MaxRange with chart.Params.Range.
 	Max Interval = "max"
 )
 
+// intervalDays is the longest span, in days, that yahoo
+// serves bars of each interval for.
+var intervalDays = map[Interval]int{
+	OneMin:      7,
+	TwoMins:     60,
+	FiveMins:    60,
+	FifteenMins: 60,
+	ThirtyMins:  60,
+	NinetyMins:  60,
+	SixtyMins:   730,
+	OneHour:     730,
+	OneDay:      -1,
+	FiveDay:     -1,
+	OneWeek:     -1,
+	OneMonth:    -1,
+	ThreeMonth:  -1,
+}
+
+// Valid reports whether i is a bar interval
+// accepted by yahoo.
+func (i Interval) Valid() bool {
+	_, ok := intervalDays[i]
+	return ok
+}
+
+// MaxDays returns the longest span, in days, that yahoo serves
+// bars of interval i for, or 0 if it is not limited or unknown.
+func (i Interval) MaxDays() int {
+	if days := intervalDays[i]; days > 0 {
+		return days
+	}
+	return 0
+}
+
+// Supports reports whether bars of interval i
+// can be requested over range r.
+func (i Interval) Supports(r Range) bool {
+	limit, ok := intervalDays[i]
+	days, known := rangeDays[r]
+	if !ok || !known {
+		return false
+	}
+	return limit < 0 || (days >= 0 && days <= limit)
+}
+
+// Range is the span of a chart
+// ending at the most recent bar.
+type Range string
+
+const (
+	// OneDayRange spans 1 day.
+	OneDayRange Range = "1d"
+	// FiveDayRange spans 5 days.
+	FiveDayRange Range = "5d"
+	// OneMonthRange spans 1 month.
+	OneMonthRange Range = "1mo"
+	// ThreeMonthRange spans 3 months.
+	ThreeMonthRange Range = "3mo"
+	// SixMonthRange spans 6 months.
+	SixMonthRange Range = "6mo"
+	// OneYearRange spans 1 year.
+	OneYearRange Range = "1y"
+	// TwoYearRange spans 2 years.
+	TwoYearRange Range = "2y"
+	// FiveYearRange spans 5 years.
+	FiveYearRange Range = "5y"
+	// TenYearRange spans 10 years.
+	TenYearRange Range = "10y"
+	// YTDRange spans the year to date.
+	YTDRange Range = "ytd"
+	// MaxRange spans the whole history.
+	MaxRange Range = "max"
+)
+
+// rangeDays is the longest span, in days, of each range.
+var rangeDays = map[Range]int{
+	OneDayRange:     1,
+	FiveDayRange:    5,
+	OneMonthRange:   31,
+	ThreeMonthRange: 92,
+	SixMonthRange:   184,
+	OneYearRange:    366,
+	TwoYearRange:    730,
+	FiveYearRange:   1827,
+	TenYearRange:    3653,
+	YTDRange:        366,
+	MaxRange:        -1,
+}
+
+// Valid reports whether r is a range accepted by yahoo.
+func (r Range) Valid() bool {
+	_, ok := rangeDays[r]
+	return ok
+}
+
 // Datetime is a simple time construct,
 // that is either the start point or the end point
-// for a chart time-series.
+// for a chart time-series. It is either a full timestamp
+// or a date-only value, which stands for a whole trading day.
 type Datetime struct {
 	Day   int
 	Month int
 	Year  int
-	t     *time.Time
+	// Location is the timezone the date is read in.
+	// If nil, chart requests use the exchange timezone
+	// of the symbol and other uses fall back to UTC.
+	Location *time.Location
+	t        *time.Time
 }
 
 // New creates a new instance of Datetime from a go time struct.
 func New(t *time.Time) *Datetime {
 	year, month, day := t.Date()
 	return &Datetime{
-		Month: int(month),
-		Day:   day,
-		Year:  year,
-		t:     t,
+		Month:    int(month),
+		Day:      day,
+		Year:     year,
+		Location: t.Location(),
+		t:        t,
 	}
 }
 
@@ -75,26 +192,66 @@ func FromUnix(timestamp int) *Datetime {
 	return New(&t)
 }
 
-// Time returns a go time struct from a datetime.
-func (d *Datetime) Time() *time.Time {
+// Date returns a date-only Datetime read in loc,
+// which may be nil.
+func Date(year int, month time.Month, day int, loc *time.Location) *Datetime {
+	return &Datetime{
+		Month:    int(month),
+		Day:      day,
+		Year:     year,
+		Location: loc,
+	}
+}
+
+// IsDate reports whether d is date-only.
+func (d *Datetime) IsDate() bool {
+	return d.t == nil
+}
+
+// In returns a copy of d read in loc. A full timestamp
+// keeps its instant, a date-only value keeps its date.
+func (d *Datetime) In(loc *time.Location) *Datetime {
 	if d.t != nil {
-		return d.t
+		t := d.t.In(loc)
+		return New(&t)
 	}
-	d.calculateTime()
-	return d.Time()
+	return Date(d.Year, time.Month(d.Month), d.Day, loc)
 }
 
-// Unix returns a valid unix timestamp from Datetime fields.
-func (d *Datetime) Unix() int {
+// SessionStart returns the first instant of the trading day
+// of a date-only value, or the timestamp of a full one.
+func (d *Datetime) SessionStart() time.Time {
+	if d.t != nil {
+		return *d.t
+	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, d.location())
+}
+
+// SessionEnd returns the last second of the trading day
+// of a date-only value, or the timestamp of a full one.
+func (d *Datetime) SessionEnd() time.Time {
 	if d.t != nil {
-		return int(d.t.Unix())
+		return *d.t
 	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day+1, 0, 0, -1, 0, d.location())
+}
+
+// Time returns a go time struct from a datetime.
+// A date-only value yields the start of its trading day.
+func (d *Datetime) Time() *time.Time {
+	t := d.SessionStart()
+	return &t
+}
 
-	d.calculateTime()
-	return d.Unix()
+// Unix returns a valid unix timestamp from Datetime fields.
+// A date-only value yields the start of its trading day.
+func (d *Datetime) Unix() int {
+	return int(d.SessionStart().Unix())
 }
 
-func (d *Datetime) calculateTime() {
-	t := time.Date(d.Year, time.Month(d.Month), d.Day, 9, 30, 0, 0, time.Local)
-	d.t = &t
+func (d *Datetime) location() *time.Location {
+	if d.Location == nil {
+		return time.UTC
+	}
+	return d.Location
 }
@@ -0,0 +1,62 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalSupportsRange(t *testing.T) {
+	assert.True(t, OneMin.Supports(FiveDayRange))
+	assert.False(t, OneMin.Supports(OneMonthRange))
+	assert.True(t, FiveMins.Supports(OneMonthRange))
+	assert.False(t, FiveMins.Supports(YTDRange))
+	assert.True(t, OneHour.Supports(TwoYearRange))
+	assert.False(t, OneHour.Supports(MaxRange))
+	assert.True(t, OneDay.Supports(MaxRange))
+	assert.False(t, OneDay.Supports(Range("7d")))
+	assert.False(t, YTD.Valid())
+	assert.True(t, OneWeek.Valid())
+	assert.True(t, YTDRange.Valid())
+}
+
+func TestIntervalMaxDays(t *testing.T) {
+	assert.Equal(t, 7, OneMin.MaxDays())
+	assert.Equal(t, 60, FiveMins.MaxDays())
+	assert.Equal(t, 730, OneHour.MaxDays())
+	assert.Equal(t, 0, OneDay.MaxDays())
+	assert.Equal(t, 0, YTD.MaxDays())
+}
+
+func TestDateSession(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	d := Date(2018, time.January, 11, ny)
+	assert.True(t, d.IsDate())
+	assert.Equal(t, time.Date(2018, 1, 11, 5, 0, 0, 0, time.UTC).Unix(), d.SessionStart().Unix())
+	assert.Equal(t, time.Date(2018, 1, 12, 4, 59, 59, 0, time.UTC).Unix(), d.SessionEnd().Unix())
+	assert.Equal(t, int(d.SessionStart().Unix()), d.Unix())
+	assert.True(t, d.IsDate())
+
+	// Dates without a location are read in UTC.
+	d = &Datetime{Year: 2018, Month: 1, Day: 11}
+	assert.Equal(t, int(time.Date(2018, 1, 11, 0, 0, 0, 0, time.UTC).Unix()), d.Unix())
+	assert.Equal(t, ny, d.In(ny).Location)
+	assert.Equal(t, 11, d.In(ny).Day)
+}
+
+func TestTimestampSession(t *testing.T) {
+	ts := time.Date(2018, 1, 11, 23, 30, 0, 0, time.UTC)
+	d := New(&ts)
+	assert.False(t, d.IsDate())
+	assert.Equal(t, ts, d.SessionStart())
+	assert.Equal(t, ts, d.SessionEnd())
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.Nil(t, err)
+	in := d.In(tokyo)
+	assert.Equal(t, 12, in.Day)
+	assert.Equal(t, d.Unix(), in.Unix())
+}
@@ -1,7 +1,10 @@
 package finance
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 )
 
 const (
@@ -13,28 +16,260 @@ const (
 	// communicated in a reponse from a
 	// remote api source.
 	remoteErrorCode = "remote-error"
+
+	// decodeErrorCode denotes a response
+	// that could not be decoded.
+	decodeErrorCode = "decode-error"
+
+	// notFoundErrorCode denotes a request
+	// for a symbol that yielded no results.
+	notFoundErrorCode = "not-found-error"
+
+	// bodySnippetLen is the maximum number of response
+	// body bytes kept on an error.
+	bodySnippetLen = 256
+)
+
+// Sentinel errors to be used with errors.Is.
+// Every error returned by this library matches
+// at most one of ErrArgument, ErrRemote and ErrDecode,
+// except a *BatchError, which matches those of each
+// of its failed batches.
+var (
+	// ErrArgument matches errors caused by invalid function arguments.
+	ErrArgument = errors.New(apiErrorCode)
+	// ErrRemote matches errors reported by, or while reaching, the remote api.
+	ErrRemote = errors.New(remoteErrorCode)
+	// ErrDecode matches responses that could not be decoded.
+	ErrDecode = errors.New(decodeErrorCode)
+	// ErrNotFound matches requests for symbols that yielded no results.
+	// It may be matched in addition to ErrRemote.
+	ErrNotFound = errors.New(notFoundErrorCode)
 )
 
+// ArgumentError is returned when a function
+// is called with missing or invalid arguments.
+type ArgumentError struct {
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("code: %s, detail: %s", apiErrorCode, e.Detail)
+}
+
+// Is reports whether target is ErrArgument.
+func (e *ArgumentError) Is(target error) bool {
+	return target == ErrArgument
+}
+
+// ChartTimeError is returned when a chart request
+// has a start time more recent than its end time.
+type ChartTimeError struct {
+	// Start and End are the requested bounds, in unix seconds.
+	Start int
+	End   int
+}
+
+// Error implements the error interface.
+func (e *ChartTimeError) Error() string {
+	return fmt.Sprintf("code: %s, detail: start time %d cannot be more recent than end time %d", apiErrorCode, e.Start, e.End)
+}
+
+// Is reports whether target is ErrArgument.
+func (e *ChartTimeError) Is(target error) bool {
+	return target == ErrArgument
+}
+
+// HTTPError is returned when the remote api
+// responds with an error status code.
+type HTTPError struct {
+	// StatusCode is the response status code.
+	StatusCode int
+	// URL is the request URL, with the crumb removed.
+	URL string
+	// Body holds the first bytes of the response body.
+	Body string
+	// Upstream is the error object found in the response body, if any.
+	Upstream *YfinError
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	detail := fmt.Sprintf("upstream api responded %d for %s", e.StatusCode, e.URL)
+	if e.Upstream != nil {
+		detail += ": " + e.Upstream.Description
+	}
+	return fmt.Sprintf("code: %s, detail: %s", remoteErrorCode, detail)
+}
+
+// Is reports whether target is ErrRemote, or ErrNotFound
+// for 404 responses.
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrRemote || (target == ErrNotFound && e.StatusCode == 404)
+}
+
+// Unwrap returns the upstream error, if any.
+func (e *HTTPError) Unwrap() error {
+	if e.Upstream == nil {
+		return nil
+	}
+	return e.Upstream
+}
+
+// DecodeError is returned when a response
+// body cannot be decoded.
+type DecodeError struct {
+	// Err is the underlying decoding error.
+	Err error
+	// Body holds the first bytes of the response body.
+	Body string
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("code: %s, detail: %s", decodeErrorCode, e.Err.Error())
+}
+
+// Is reports whether target is ErrDecode.
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// NotFoundError is returned when a request
+// for a symbol yields no results.
+type NotFoundError struct {
+	Symbol string
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("Can't find quote for symbol: %s", e.Symbol)
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// RemoteError wraps an error that occurred
+// while talking to the remote api.
+type RemoteError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("code: %s, detail: %s", remoteErrorCode, e.Err.Error())
+}
+
+// Is reports whether target is ErrRemote.
+func (e *RemoteError) Is(target error) bool {
+	return target == ErrRemote
+}
+
+// Unwrap returns the wrapped error.
+func (e *RemoteError) Unwrap() error {
+	return e.Err
+}
+
 // CreateArgumentError returns an error
 // with a message about missing arguments.
 func CreateArgumentError() error {
-	return fmt.Errorf("code: %s, detail: %s", apiErrorCode, "missing function argument")
+	return &ArgumentError{Detail: "missing function argument"}
+}
+
+// CreateArgumentErrorS returns an error
+// with a message about invalid arguments.
+func CreateArgumentErrorS(detail string) error {
+	return &ArgumentError{Detail: detail}
 }
 
 // CreateChartTimeError returns an error
-// with a message improper chart arguments.
-func CreateChartTimeError() error {
-	return fmt.Errorf("code: %s, detail: %s", apiErrorCode, "start time cannot be more recent than end time")
+// about a chart starting at start, after its end.
+func CreateChartTimeError(start, end int) error {
+	return &ChartTimeError{Start: start, End: end}
+}
+
+// CreateNotFoundError returns an error
+// about a symbol that yielded no results.
+func CreateNotFoundError(symbol string) error {
+	return &NotFoundError{Symbol: symbol}
 }
 
 // CreateRemoteError returns an error
 // with a message about a remote api problem.
+// Errors already classified by this library
+// are returned unchanged.
 func CreateRemoteError(e error) error {
-	return fmt.Errorf("code: %s, detail: %s", remoteErrorCode, e.Error())
+	if e == nil {
+		return nil
+	}
+	if errors.Is(e, ErrRemote) || errors.Is(e, ErrArgument) || errors.Is(e, ErrDecode) {
+		return e
+	}
+	return &RemoteError{Err: e}
 }
 
 // CreateRemoteErrorS returns an error
 // with a message about a remote api problem.
 func CreateRemoteErrorS(str string) error {
-	return fmt.Errorf("code: %s, detail: %s", remoteErrorCode, str)
+	return &RemoteError{Err: errors.New(str)}
+}
+
+// createHTTPError builds an HTTPError from an error response,
+// picking up the yfin error object from the body if there is one.
+func createHTTPError(statusCode int, u *url.URL, body []byte) error {
+	e := &HTTPError{
+		StatusCode: statusCode,
+		URL:        redactURL(u),
+		Body:       snippet(body),
+	}
+
+	// Error bodies look like {"chart":{"result":null,"error":{...}}}.
+	envelope := map[string]struct {
+		Error *YfinError `json:"error"`
+	}{}
+	if json.Unmarshal(body, &envelope) == nil {
+		for _, inner := range envelope {
+			if inner.Error != nil {
+				e.Upstream = inner.Error
+				break
+			}
+		}
+	}
+	return e
+}
+
+// CreateDecodeError returns an error about a body that failed
+// to decode, carrying its first bytes.
+func CreateDecodeError(err error, body []byte) error {
+	return &DecodeError{Err: err, Body: snippet(body)}
+}
+
+// redactURL renders u without its crumb parameter.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	r := *u
+	q := r.Query()
+	if q.Get("crumb") != "" {
+		q.Del("crumb")
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
+
+// snippet returns at most bodySnippetLen bytes of body.
+func snippet(body []byte) string {
+	if len(body) > bodySnippetLen {
+		body = body[:bodySnippetLen]
+	}
+	return string(body)
 }
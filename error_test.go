@@ -0,0 +1,126 @@
+package finance
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgumentErrors(t *testing.T) {
+	err := CreateArgumentError()
+	assert.True(t, errors.Is(err, ErrArgument))
+	assert.False(t, errors.Is(err, ErrRemote))
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+
+	err = CreateChartTimeError(1515681000, 1515594600)
+	var cte *ChartTimeError
+	assert.True(t, errors.As(err, &cte))
+	assert.Equal(t, 1515681000, cte.Start)
+	assert.Equal(t, 1515594600, cte.End)
+	assert.True(t, errors.Is(err, ErrArgument))
+}
+
+func TestRemoteErrorNoDoubleWrap(t *testing.T) {
+	inner := errors.New("connection refused")
+	err := CreateRemoteError(inner)
+	assert.True(t, errors.Is(err, ErrRemote))
+	assert.True(t, errors.Is(err, inner))
+	assert.Equal(t, "code: remote-error, detail: connection refused", err.Error())
+
+	assert.Equal(t, err, CreateRemoteError(err))
+
+	yerr := &YfinError{Code: "Not Found", Description: "No data found"}
+	assert.Equal(t, error(yerr), CreateRemoteError(yerr))
+	assert.True(t, errors.Is(yerr, ErrRemote))
+	assert.True(t, errors.Is(yerr, ErrNotFound))
+
+	assert.Nil(t, CreateRemoteError(nil))
+}
+
+func TestNotFoundError(t *testing.T) {
+	err := CreateNotFoundError("TEST")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, "Can't find quote for symbol: TEST", err.Error())
+}
+
+func TestHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`))
+	}))
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+
+	err := b.Call("/v8/finance/chart/BADSYMBOL", nil, nil, nil)
+
+	var herr *HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusNotFound, herr.StatusCode)
+	assert.Equal(t, srv.URL+"/v8/finance/chart/BADSYMBOL", herr.URL)
+	assert.True(t, strings.HasPrefix(herr.Body, `{"chart"`))
+	assert.True(t, errors.Is(err, ErrRemote))
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	var yerr *YfinError
+	assert.True(t, errors.As(err, &yerr))
+	assert.Equal(t, "Not Found", yerr.Code)
+	assert.Equal(t, err, CreateRemoteError(err))
+}
+
+func TestHTTPErrorRedactsCrumb(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(strings.Repeat("x", 2*bodySnippetLen)))
+	}))
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+
+	err := b.Call("/v7/finance/quote?symbols=AAPL&crumb=secret", nil, nil, nil)
+
+	var herr *HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.False(t, strings.Contains(herr.URL, "secret"))
+	assert.True(t, strings.Contains(herr.URL, "symbols=AAPL"))
+	assert.Len(t, herr.Body, bodySnippetLen)
+	assert.Nil(t, herr.Upstream)
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
+
+func TestDecodeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteResponse":`))
+	}))
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+
+	var v map[string]interface{}
+	err := b.Call("/", nil, nil, &v)
+
+	var derr *DecodeError
+	assert.True(t, errors.As(err, &derr))
+	assert.Equal(t, `{"quoteResponse":`, derr.Body)
+	assert.True(t, errors.Is(err, ErrDecode))
+	assert.False(t, errors.Is(err, ErrRemote))
+
+	var serr *json.SyntaxError
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, err, CreateRemoteError(err))
+}
+
+func TestTransportErrorIsRemote(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: url, HTTPClient: &http.Client{}}
+
+	err := b.Call("/", nil, nil, nil)
+
+	var rerr *RemoteError
+	assert.True(t, errors.As(err, &rerr))
+	assert.True(t, errors.Is(err, ErrRemote))
+}
@@ -1,7 +1,9 @@
 package etf
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	finance "github.com/piquette/finance-go"
 	tests "github.com/piquette/finance-go/testing"
@@ -56,3 +58,48 @@ func TestGetBadETF(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+func TestProfile(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("fund profiles are only served by the in-process server")
+	}
+
+	p, err := Profile(tests.TestETFSymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, "SPDR S&P 500 ETF Trust", p.Name)
+	assert.Equal(t, "SPDR State Street Global Advisors", p.Family)
+	assert.Equal(t, "Large Blend", p.Category)
+	assert.Equal(t, time.Date(1993, 1, 22, 0, 0, 0, 0, time.UTC), p.InceptionDate)
+	assert.Equal(t, "0.09%", p.ExpenseRatio.Fmt)
+	assert.Equal(t, 0.000945, p.ExpenseRatio.Raw)
+	assert.Equal(t, int64(470443000000), p.TotalAssets.Int())
+	assert.Equal(t, 0.0144, p.Yield.Raw)
+
+	assert.Len(t, p.Holdings, 3)
+	assert.Equal(t, "AAPL", p.Holdings[0].Symbol)
+	assert.Equal(t, "Apple Inc", p.Holdings[0].Name)
+	assert.Equal(t, 0.0703, p.Holdings[0].Weight.Raw)
+	assert.Equal(t, 0.9993, p.Allocation.Stock.Raw)
+	assert.Equal(t, 0.0007, p.Allocation.Cash.Raw)
+	assert.Equal(t, 0.2861, p.SectorWeightings["technology"].Raw)
+	assert.Len(t, p.SectorWeightings, 4)
+	assert.Contains(t, p.BondRatings, "aaa")
+
+	assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), p.Performance.AsOf)
+	assert.Equal(t, 0.2619, p.Performance.YTD.Raw)
+	assert.Equal(t, 0.1199, p.Performance.TenYear.Raw)
+	assert.Equal(t, -0.1817, p.Performance.Annual[2022].Raw)
+	assert.NotContains(t, p.Performance.Annual, 2020)
+}
+
+func TestBadProfile(t *testing.T) {
+	_, err := ProfileP(nil)
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+
+	if tests.DefaultServer == nil {
+		return
+	}
+	// Equities have no fund profile.
+	_, err = Profile(tests.TestEquitySymbol)
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
@@ -0,0 +1,82 @@
+package finance
+
+import "time"
+
+// CorporateEvents are the upcoming earnings
+// and dividend dates of a company.
+type CorporateEvents struct {
+	Symbol string
+	// EarningsStart and EarningsEnd bound the next earnings
+	// date. They are equal once the date is confirmed.
+	EarningsStart time.Time
+	EarningsEnd   time.Time
+	// EarningsEstimated is true until the company
+	// confirms the earnings date.
+	EarningsEstimated bool
+	EarningsCall      time.Time
+	EarningsAverage   Value
+	EarningsLow       Value
+	EarningsHigh      Value
+	RevenueAverage    Value
+	RevenueLow        Value
+	RevenueHigh       Value
+	ExDividendDate    time.Time
+	// DividendDate is the pay date of the next dividend.
+	DividendDate time.Time
+}
+
+// EarningsEvent is an earnings release
+// of the market-wide calendar.
+type EarningsEvent struct {
+	Symbol string
+	Name   string
+	Date   time.Time
+	// Timing is BMO (before market open), AMC (after
+	// market close), TAS (at a set time) or TNS (not set).
+	Timing          string
+	EPSEstimate     Value
+	EPSActual       Value
+	SurprisePercent Value
+}
+
+// IPOEvent is an initial public offering
+// of the market-wide calendar.
+type IPOEvent struct {
+	Symbol   string
+	Name     string
+	Exchange string
+	Date     time.Time
+	// Action is Expected, Priced, Filed, Amended or Withdrawn.
+	Action    string
+	PriceFrom Value
+	PriceTo   Value
+	Price     Value
+	Shares    Value
+	Currency  string
+}
+
+// SplitEvent is a stock split
+// of the market-wide calendar.
+type SplitEvent struct {
+	Symbol string
+	Name   string
+	Date   time.Time
+	// New shares are issued for every Old share.
+	Old        Value
+	New        Value
+	Optionable bool
+}
+
+// EconomicEvent is a release of an economic
+// indicator of the market-wide calendar.
+type EconomicEvent struct {
+	Name    string
+	Country string
+	Date    time.Time
+	// Period is the period the release covers, such as Q3 or Dec.
+	Period   string
+	Actual   Value
+	Expected Value
+	Prior    Value
+	Revised  Value
+}
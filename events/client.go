@@ -0,0 +1,111 @@
+// Package events looks up corporate events: the upcoming earnings
+// and dividend dates of a company, and the market-wide calendars
+// of earnings, IPOs, splits and economic releases.
+package events
+
+import (
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/summary"
+)
+
+// Client is used to invoke corporate event APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	finance.Params `form:"-"`
+	// Symbol is the company whose events are requested.
+	Symbol string `form:"-"`
+}
+
+// Get returns the upcoming events of a symbol.
+func Get(symbol string) (*finance.CorporateEvents, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns the upcoming events of a symbol
+// and requires a params struct as an argument.
+func GetP(params *Params) (*finance.CorporateEvents, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns the upcoming events of a symbol.
+func (c Client) GetP(params *Params) (*finance.CorporateEvents, error) {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	r := result{}
+	err := summary.Client{B: c.B}.Decode(&summary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []summary.Module{summary.CalendarEvents},
+	}, &r)
+	if err != nil {
+		return nil, err
+	}
+	if r.CalendarEvents == nil {
+		return nil, finance.CreateNotFoundError(params.Symbol)
+	}
+
+	ce := r.CalendarEvents
+	e := &finance.CorporateEvents{
+		Symbol:            params.Symbol,
+		EarningsEstimated: ce.Earnings.Estimated,
+		EarningsAverage:   ce.Earnings.EarningsAverage,
+		EarningsLow:       ce.Earnings.EarningsLow,
+		EarningsHigh:      ce.Earnings.EarningsHigh,
+		RevenueAverage:    ce.Earnings.RevenueAverage,
+		RevenueLow:        ce.Earnings.RevenueLow,
+		RevenueHigh:       ce.Earnings.RevenueHigh,
+		ExDividendDate:    ce.ExDividendDate.Time,
+		DividendDate:      ce.DividendDate.Time,
+	}
+	e.EarningsStart, e.EarningsEnd = span(ce.Earnings.Dates)
+	e.EarningsCall, _ = span(ce.Earnings.CallDates)
+	return e, nil
+}
+
+// span returns the first and last of dates.
+func span(dates []finance.Date) (first, last time.Time) {
+	for _, d := range dates {
+		if !d.Valid {
+			continue
+		}
+		if first.IsZero() || d.Time.Before(first) {
+			first = d.Time
+		}
+		if d.Time.After(last) {
+			last = d.Time
+		}
+	}
+	return first, last
+}
+
+// result is the quoteSummary result of the calendarEvents module.
+type result struct {
+	CalendarEvents *struct {
+		Earnings struct {
+			Dates           []finance.Date `json:"earningsDate"`
+			CallDates       []finance.Date `json:"earningsCallDate"`
+			Estimated       bool           `json:"isEarningsDateEstimate"`
+			EarningsAverage finance.Value  `json:"earningsAverage"`
+			EarningsLow     finance.Value  `json:"earningsLow"`
+			EarningsHigh    finance.Value  `json:"earningsHigh"`
+			RevenueAverage  finance.Value  `json:"revenueAverage"`
+			RevenueLow      finance.Value  `json:"revenueLow"`
+			RevenueHigh     finance.Value  `json:"revenueHigh"`
+		} `json:"earnings"`
+		ExDividendDate finance.Date `json:"exDividendDate"`
+		DividendDate   finance.Date `json:"dividendDate"`
+	} `json:"calendarEvents"`
+}
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("calendar events are only served by the in-process server")
+	}
+}
+
+func TestGet(t *testing.T) {
+	skipMock(t)
+
+	e, err := Get(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, tests.TestEquitySymbol, e.Symbol)
+	assert.Equal(t, time.Date(2024, 2, 1, 21, 0, 0, 0, time.UTC), e.EarningsStart)
+	assert.Equal(t, time.Date(2024, 2, 5, 21, 0, 0, 0, time.UTC), e.EarningsEnd)
+	assert.Equal(t, time.Date(2024, 2, 1, 22, 0, 0, 0, time.UTC), e.EarningsCall)
+	assert.True(t, e.EarningsEstimated)
+	assert.Equal(t, 2.1, e.EarningsAverage.Raw)
+	assert.Equal(t, "117.91B", e.RevenueAverage.Fmt)
+	assert.Equal(t, time.Date(2023, 11, 10, 0, 0, 0, 0, time.UTC), e.ExDividendDate)
+	assert.Equal(t, time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC), e.DividendDate)
+}
+
+func TestBadSymbol(t *testing.T) {
+	skipMock(t)
+
+	_, err := Get("BADSYMBOL")
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+func TestNoEvents(t *testing.T) {
+	skipMock(t)
+
+	// Funds have no calendarEvents module.
+	_, err := Get(tests.TestETFSymbol)
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+func TestNilParams(t *testing.T) {
+	_, err := GetP(nil)
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+}
@@ -0,0 +1,310 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/iter"
+)
+
+// DefaultPerDay is the number of events of each
+// day requested when CalendarParams.PerDay is zero.
+const DefaultPerDay = 100
+
+// CalendarParams carries a context and the
+// date range of a market-wide calendar.
+type CalendarParams struct {
+	finance.Params `form:"-"`
+	// Start is the first day of the calendar.
+	Start *datetime.Datetime `form:"-"`
+	// End is the last day of the calendar, Start if nil.
+	End *datetime.Datetime `form:"-"`
+	// PerDay is the largest number of events of each day.
+	PerDay int `form:"-"`
+
+	modules   string `form:"modules"`
+	startDate int64  `form:"startDate"`
+	endDate   int64  `form:"endDate"`
+	perDay    int    `form:"countPerDay"`
+}
+
+// EarningsIter is an iterator for the earnings calendar.
+// The embedded iter.Of carries methods with it;
+// see its documentation for details.
+type EarningsIter struct {
+	*iter.Of[*finance.EarningsEvent]
+}
+
+// Event returns the most recent event
+// visited by a call to Next.
+func (i *EarningsIter) Event() *finance.EarningsEvent {
+	return i.Current()
+}
+
+// IPOIter is an iterator for the IPO calendar.
+type IPOIter struct {
+	*iter.Of[*finance.IPOEvent]
+}
+
+// Event returns the most recent event
+// visited by a call to Next.
+func (i *IPOIter) Event() *finance.IPOEvent {
+	return i.Current()
+}
+
+// SplitIter is an iterator for the splits calendar.
+type SplitIter struct {
+	*iter.Of[*finance.SplitEvent]
+}
+
+// Event returns the most recent event
+// visited by a call to Next.
+func (i *SplitIter) Event() *finance.SplitEvent {
+	return i.Current()
+}
+
+// EconomicIter is an iterator for the economic calendar.
+type EconomicIter struct {
+	*iter.Of[*finance.EconomicEvent]
+}
+
+// Event returns the most recent event
+// visited by a call to Next.
+func (i *EconomicIter) Event() *finance.EconomicEvent {
+	return i.Current()
+}
+
+// Earnings returns the earnings releases between start and end.
+func Earnings(start, end *datetime.Datetime) *EarningsIter {
+	return EarningsP(&CalendarParams{Start: start, End: end})
+}
+
+// EarningsP returns the earnings calendar
+// and requires a params struct as an argument.
+func EarningsP(params *CalendarParams) *EarningsIter {
+	return getC().EarningsP(params)
+}
+
+// EarningsP returns the earnings calendar.
+func (c Client) EarningsP(params *CalendarParams) *EarningsIter {
+	return &EarningsIter{calendar(c, params, "earnings", func(r *record) *finance.EarningsEvent {
+		return &finance.EarningsEvent{
+			Symbol:          r.Ticker,
+			Name:            r.CompanyShortName,
+			Date:            r.StartDateTime.time(),
+			Timing:          r.StartDateTimeType,
+			EPSEstimate:     r.EPSEstimate,
+			EPSActual:       r.EPSActual,
+			SurprisePercent: r.EPSSurprisePct,
+		}
+	})}
+}
+
+// IPOs returns the initial public offerings between start and end.
+func IPOs(start, end *datetime.Datetime) *IPOIter {
+	return IPOsP(&CalendarParams{Start: start, End: end})
+}
+
+// IPOsP returns the IPO calendar
+// and requires a params struct as an argument.
+func IPOsP(params *CalendarParams) *IPOIter {
+	return getC().IPOsP(params)
+}
+
+// IPOsP returns the IPO calendar.
+func (c Client) IPOsP(params *CalendarParams) *IPOIter {
+	return &IPOIter{calendar(c, params, "ipoEvents", func(r *record) *finance.IPOEvent {
+		return &finance.IPOEvent{
+			Symbol:    r.Ticker,
+			Name:      r.CompanyShortName,
+			Exchange:  r.ExchangeShortName,
+			Date:      r.StartDateTime.time(),
+			Action:    r.DealType,
+			PriceFrom: r.PriceFrom,
+			PriceTo:   r.PriceTo,
+			Price:     r.OfferPrice,
+			Shares:    r.Shares,
+			Currency:  r.CurrencyName,
+		}
+	})}
+}
+
+// Splits returns the stock splits between start and end.
+func Splits(start, end *datetime.Datetime) *SplitIter {
+	return SplitsP(&CalendarParams{Start: start, End: end})
+}
+
+// SplitsP returns the splits calendar
+// and requires a params struct as an argument.
+func SplitsP(params *CalendarParams) *SplitIter {
+	return getC().SplitsP(params)
+}
+
+// SplitsP returns the splits calendar.
+func (c Client) SplitsP(params *CalendarParams) *SplitIter {
+	return &SplitIter{calendar(c, params, "splits", func(r *record) *finance.SplitEvent {
+		return &finance.SplitEvent{
+			Symbol:     r.Ticker,
+			Name:       r.CompanyShortName,
+			Date:       r.StartDateTime.time(),
+			Old:        r.OldShareWorth,
+			New:        r.NewShareWorth,
+			Optionable: r.Optionable,
+		}
+	})}
+}
+
+// Economic returns the economic releases between start and end.
+func Economic(start, end *datetime.Datetime) *EconomicIter {
+	return EconomicP(&CalendarParams{Start: start, End: end})
+}
+
+// EconomicP returns the economic calendar
+// and requires a params struct as an argument.
+func EconomicP(params *CalendarParams) *EconomicIter {
+	return getC().EconomicP(params)
+}
+
+// EconomicP returns the economic calendar.
+func (c Client) EconomicP(params *CalendarParams) *EconomicIter {
+	return &EconomicIter{calendar(c, params, "economicEvents", func(r *record) *finance.EconomicEvent {
+		return &finance.EconomicEvent{
+			Name:     r.Event,
+			Country:  r.CountryCode,
+			Date:     r.EventTime.time(),
+			Period:   r.Period,
+			Actual:   r.Actual,
+			Expected: r.ConsensusEstimate,
+			Prior:    r.Prior,
+			Revised:  r.RevisedFrom,
+		}
+	})}
+}
+
+// calendar returns an iterator over the events of a module
+// of the market-wide calendar, in order of their day.
+func calendar[T any](c Client, params *CalendarParams, module string, event func(*record) T) *iter.Of[T] {
+
+	if params == nil || params.Start == nil || params.PerDay < 0 {
+		return iter.NewOfE[T](finance.CreateArgumentError())
+	}
+	end := params.End
+	if end == nil {
+		end = params.Start
+	}
+	if end.SessionEnd().Before(params.Start.SessionStart()) {
+		return iter.NewOfE[T](finance.CreateArgumentErrorS("calendar ends before it starts"))
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	params.modules = module
+	params.startDate = params.Start.SessionStart().UnixMilli()
+	params.endDate = end.SessionEnd().UnixMilli()
+	params.perDay = params.PerDay
+	if params.perDay == 0 {
+		params.perDay = DefaultPerDay
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	return iter.New(body, func(b *form.Values) (interface{}, []T, error) {
+
+		resp := response{}
+		err := c.B.Call("/ws/screeners/v1/finance/calendar-events", b, params.Context, &resp)
+		if err != nil {
+			return nil, nil, finance.CreateRemoteError(err)
+		}
+		if resp.Inner.Error != nil {
+			return nil, nil, resp.Inner.Error
+		}
+		if len(resp.Inner.Result) == 0 {
+			return nil, nil, nil
+		}
+
+		var m struct {
+			ResultsByDay []*struct {
+				Records []*record `json:"records"`
+			} `json:"resultsByDay"`
+		}
+		if raw, ok := resp.Inner.Result[0][module]; ok {
+			if err := json.Unmarshal(raw, &m); err != nil {
+				return nil, nil, finance.CreateDecodeError(err, raw)
+			}
+		}
+
+		var events []T
+		for _, day := range m.ResultsByDay {
+			if day == nil {
+				continue
+			}
+			for _, r := range day.Records {
+				if r != nil {
+					events = append(events, event(r))
+				}
+			}
+		}
+		return nil, events, nil
+	})
+}
+
+// millis is a time in unix milliseconds.
+type millis struct {
+	finance.Value
+}
+
+func (m millis) time() time.Time {
+	if !m.Valid {
+		return time.Time{}
+	}
+	return time.UnixMilli(m.Int()).UTC()
+}
+
+// record is an event of any module of the calendar.
+type record struct {
+	Ticker            string `json:"ticker"`
+	CompanyShortName  string `json:"companyShortName"`
+	StartDateTime     millis `json:"startDateTime"`
+	StartDateTimeType string `json:"startDateTimeType"`
+
+	EPSEstimate    finance.Value `json:"epsEstimate"`
+	EPSActual      finance.Value `json:"epsActual"`
+	EPSSurprisePct finance.Value `json:"epsSurprisePct"`
+
+	ExchangeShortName string        `json:"exchangeShortName"`
+	DealType          string        `json:"dealType"`
+	PriceFrom         finance.Value `json:"priceFrom"`
+	PriceTo           finance.Value `json:"priceTo"`
+	OfferPrice        finance.Value `json:"offerPrice"`
+	Shares            finance.Value `json:"shares"`
+	CurrencyName      string        `json:"currencyName"`
+
+	OldShareWorth finance.Value `json:"oldShareWorth"`
+	NewShareWorth finance.Value `json:"newShareWorth"`
+	Optionable    bool          `json:"optionable"`
+
+	Event             string        `json:"event"`
+	CountryCode       string        `json:"countryCode"`
+	EventTime         millis        `json:"eventTime"`
+	Period            string        `json:"period"`
+	Actual            finance.Value `json:"actual"`
+	ConsensusEstimate finance.Value `json:"consensusEstimate"`
+	Prior             finance.Value `json:"prior"`
+	RevisedFrom       finance.Value `json:"revisedFrom"`
+}
+
+// response is a yfin calendar events response.
+type response struct {
+	Inner struct {
+		Result []map[string]json.RawMessage `json:"result"`
+		Error  *finance.YfinError           `json:"error"`
+	} `json:"finance"`
+}
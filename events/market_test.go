@@ -0,0 +1,104 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarnings(t *testing.T) {
+	skipMock(t)
+
+	i := Earnings(datetime.Date(2024, 1, 12, time.UTC), nil)
+	var syms []string
+	for i.Next() {
+		syms = append(syms, i.Event().Symbol)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []string{"JPM", "C"}, syms)
+
+	i = Earnings(datetime.Date(2024, 2, 1, time.UTC), datetime.Date(2024, 2, 29, time.UTC))
+	assert.True(t, i.Next())
+	e := i.Event()
+	assert.Equal(t, "Apple Inc.", e.Name)
+	assert.Equal(t, time.Date(2024, 2, 1, 21, 0, 0, 0, time.UTC), e.Date)
+	assert.Equal(t, "AMC", e.Timing)
+	assert.Equal(t, 2.1, e.EPSEstimate.Raw)
+	assert.False(t, e.EPSActual.Valid)
+	assert.False(t, i.Next())
+}
+
+func TestEarningsPerDay(t *testing.T) {
+	skipMock(t)
+
+	i := EarningsP(&CalendarParams{Start: datetime.Date(2024, 1, 12, time.UTC), PerDay: 1})
+	for i.Next() {
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, 1, i.Count())
+}
+
+func TestIPOs(t *testing.T) {
+	skipMock(t)
+
+	i := IPOs(datetime.Date(2023, 9, 1, time.UTC), datetime.Date(2023, 10, 31, time.UTC))
+	assert.True(t, i.Next())
+	e := i.Event()
+	assert.Equal(t, "ARM", e.Symbol)
+	assert.Equal(t, "NASDAQ", e.Exchange)
+	assert.Equal(t, "Priced", e.Action)
+	assert.Equal(t, 51.0, e.Price.Raw)
+	assert.Equal(t, int64(95500000), e.Shares.Int())
+	assert.True(t, i.Next())
+	assert.Equal(t, "Expected", i.Event().Action)
+	assert.False(t, i.Event().Price.Valid)
+	assert.False(t, i.Next())
+	assert.Nil(t, i.Err())
+}
+
+func TestSplits(t *testing.T) {
+	skipMock(t)
+
+	i := Splits(datetime.Date(2024, 6, 10, time.UTC), nil)
+	assert.True(t, i.Next())
+	e := i.Event()
+	assert.Equal(t, "NVDA", e.Symbol)
+	assert.Equal(t, 1.0, e.Old.Raw)
+	assert.Equal(t, 10.0, e.New.Raw)
+	assert.True(t, e.Optionable)
+	assert.False(t, i.Next())
+}
+
+func TestEconomic(t *testing.T) {
+	skipMock(t)
+
+	i := Economic(datetime.Date(2024, 1, 1, time.UTC), datetime.Date(2024, 1, 31, time.UTC))
+	var names []string
+	for i.Next() {
+		names = append(names, i.Event().Name)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []string{"CPI YY", "Initial Jobless Claims"}, names)
+}
+
+func TestEmptyCalendar(t *testing.T) {
+	skipMock(t)
+
+	i := Splits(datetime.Date(2020, 1, 1, time.UTC), nil)
+	assert.False(t, i.Next())
+	assert.Nil(t, i.Err())
+}
+
+func TestBadCalendarParams(t *testing.T) {
+	i := EarningsP(nil)
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+
+	i = Earnings(datetime.Date(2024, 2, 1, time.UTC), datetime.Date(2024, 1, 1, time.UTC))
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
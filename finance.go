@@ -1,11 +1,14 @@
 package finance
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -38,10 +41,10 @@ const (
 	// ------------------
 
 	defaultHTTPTimeout = 80 * time.Second
-	yFinURL            = "https://query1.finance.yahoo.com"
-	batsURL            = ""
+	defaultRateLimit   = 10
+	defaultRateBurst   = 20
 
-	crumbURL  = yFinURL + "/v1/test/getcrumb"
+	crumbPath = "/v1/test/getcrumb"
 	cookieURL = "https://login.yahoo.com"
 	userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/113.0"
 )
@@ -62,8 +65,11 @@ var (
 	// Private vars.
 	// -------------
 
-	httpClient = &http.Client{Timeout: defaultHTTPTimeout}
-	backends   Backends
+	httpClient  = &http.Client{Timeout: defaultHTTPTimeout}
+	retryPolicy = NewRetryPolicy()
+	rateLimiter = NewRateLimiter(defaultRateLimit, defaultRateBurst)
+	sessions    SessionProvider
+	backends    Backends
 )
 
 // SupportedBackend is an enumeration of supported api endpoints.
@@ -80,14 +86,27 @@ type BackendConfiguration struct {
 	Type       SupportedBackend
 	URL        string
 	HTTPClient *http.Client
+	// Logger receives the backend's log output, filtered by LogLevel.
+	// If nil, the package-level Logger and LogLevel are used instead.
+	Logger   Printfer
+	LogLevel int
+	// Retry is the policy applied to transient failures.
+	// A nil policy disables retries.
+	Retry *RetryPolicy
+	// Limiter throttles outgoing requests. It is shared by every
+	// client using the backend. A nil limiter disables throttling.
+	Limiter *RateLimiter
 }
 
 // yahooConfiguration is a specialization that includes a crumb and cookies for the yahoo API
 type yahooConfiguration struct {
 	BackendConfiguration
-	expiry  time.Time
-	cookies string
-	crumb   string
+	sessions SessionProvider
+
+	// mu guards current and refresh.
+	mu      sync.Mutex
+	current *Session
+	refresh *sessionRefresh
 }
 
 // Backend is an interface for making calls against an api service.
@@ -96,199 +115,209 @@ type Backend interface {
 	Call(path string, body *form.Values, ctx *context.Context, v interface{}) error
 }
 
+// PostBackend is a Backend that can also POST a JSON body,
+// which some endpoints such as the screener require.
+// The backends of this package implement it.
+type PostBackend interface {
+	Backend
+	Post(path string, params *form.Values, body interface{}, ctx *context.Context, v interface{}) error
+}
+
+// Post POSTs body to path with b, which must implement PostBackend.
+func Post(b Backend, path string, params *form.Values, body interface{}, ctx *context.Context, v interface{}) error {
+	p, ok := b.(PostBackend)
+	if !ok {
+		return CreateArgumentErrorS(fmt.Sprintf("backend %T does not support POST requests", b))
+	}
+	return p.Post(path, params, body, ctx, v)
+}
+
 // SetHTTPClient overrides the default HTTP client.
 // This is useful if you're running in a Google AppEngine environment
 // where the http.DefaultClient is not available.
 func SetHTTPClient(client *http.Client) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
 	httpClient = client
 }
 
-// NewBackends creates a new set of backends with the given HTTP client. You
-// should only need to use this for testing purposes or on App Engine.
+// SetRetryPolicy overrides the default retry policy used by
+// backends created afterwards. A nil policy disables retries.
+func SetRetryPolicy(policy *RetryPolicy) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+	retryPolicy = policy
+}
+
+// SetRateLimiter overrides the default rate limiter, which allows
+// 10 requests per second with bursts of 20, for backends created
+// afterwards. A nil limiter disables throttling.
+func SetRateLimiter(limiter *RateLimiter) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+	rateLimiter = limiter
+}
+
+// SetSessionProvider overrides how yahoo backends created afterwards
+// obtain their crumb and cookies. A nil provider restores the default,
+// which fetches them from the yahoo login and crumb endpoints.
+func SetSessionProvider(provider SessionProvider) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+	sessions = provider
+}
+
+// NewBackends creates a new set of backends with the given HTTP client
+// and the package-level settings. You should only need to use this for
+// testing purposes or on App Engine.
 func NewBackends(httpClient *http.Client) *Backends {
-	return &Backends{
-		YFin: &yahooConfiguration{
-			BackendConfiguration{YFinBackend, YFinURL, httpClient},
-			time.Time{},
-			"",
-			"",
-		},
-		Bats: &BackendConfiguration{
-			BATSBackend, BATSURL, httpClient,
-		},
+	backends.mu.RLock()
+	cfg := defaultConfig()
+	backends.mu.RUnlock()
+	cfg.HTTPClient = httpClient
+	return New(cfg)
+}
+
+// defaultConfig returns the Config of the package-level backends,
+// built from the package-level settings. The caller must hold backends.mu.
+func defaultConfig() *Config {
+	cfg := &Config{
+		HTTPClient: httpClient,
+		Retry:      retryPolicy,
+		Limiter:    rateLimiter,
+		Sessions:   sessions,
+	}
+	// A nil policy or limiter disables them here,
+	// where Config would use its defaults.
+	if cfg.Retry == nil {
+		cfg.Retry = &RetryPolicy{MaxAttempts: 1}
 	}
+	if cfg.Limiter == nil {
+		cfg.Limiter = NewRateLimiter(0, 0)
+	}
+	return cfg
+}
+
+// newYahooConfiguration returns a yahoo backend without a session.
+// A nil provider fetches sessions from the yahoo login and crumb endpoints.
+func newYahooConfiguration(b BackendConfiguration, sessions SessionProvider) *yahooConfiguration {
+	if sessions == nil {
+		sessions = NewSessionProvider(cookieURL, b.URL+crumbPath)
+	}
+	return &yahooConfiguration{BackendConfiguration: b, sessions: sessions}
 }
 
 // GetBackend returns the currently used backend in the binding.
+// Unless set with SetBackend, it is built on first use
+// through New from the package-level settings.
 func GetBackend(backend SupportedBackend) Backend {
-	switch backend {
-	case YFinBackend:
-		backends.mu.RLock()
-		ret := backends.YFin
-		backends.mu.RUnlock()
-		if ret != nil {
-			return ret
-		}
-		backends.mu.Lock()
-		defer backends.mu.Unlock()
-		backends.YFin = &yahooConfiguration{
-			BackendConfiguration{YFinBackend, YFinURL, httpClient},
-			time.Time{},
-			"",
-			"",
-		}
-		return backends.YFin
-	case BATSBackend:
-		backends.mu.RLock()
-		ret := backends.Bats
-		backends.mu.RUnlock()
-		if ret != nil {
-			return ret
-		}
-		backends.mu.Lock()
-		defer backends.mu.Unlock()
-		backends.Bats = &BackendConfiguration{backend, batsURL, httpClient}
-		return backends.Bats
+	if backend != YFinBackend && backend != BATSBackend {
+		return nil
 	}
 
-	return nil
+	backends.mu.RLock()
+	ret := backends.get(backend)
+	backends.mu.RUnlock()
+	if ret != nil {
+		return ret
+	}
+
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+	if backends.get(backend) == nil {
+		backends.set(backend, New(defaultConfig()).get(backend))
+	}
+	return backends.get(backend)
 }
 
 // SetBackend sets the backend used in the binding.
 func SetBackend(backend SupportedBackend, b Backend) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+	backends.set(backend, b)
+}
+
+// get returns the backend of the given type.
+func (b *Backends) get(backend SupportedBackend) Backend {
 	switch backend {
 	case YFinBackend:
-		backends.YFin = b
+		return b.YFin
 	case BATSBackend:
-		backends.Bats = b
+		return b.Bats
 	}
+	return nil
 }
 
-func fetchCookies() (string, time.Time, error) {
-	client := http.Client{}
-	request, err := http.NewRequest("GET", cookieURL, nil)
-	if err != nil {
-		return "", time.Time{}, err
-	}
-
-	request.Header = http.Header{
-		"Accept":                   {"*/*"},
-		"Accept-Encoding":          {"gzip, deflate, br"},
-		"Accept-Language":          {"en-US,en;q=0.5"},
-		"Connection":               {"keep-alive"},
-		"Host":                     {"login.yahoo.com"},
-		"Sec-Fetch-Dest":           {"document"},
-		"Sec-Fetch-Mode":           {"navigate"},
-		"Sec-Fetch-Site":           {"none"},
-		"Sec-Fetch-User":           {"?1"},
-		"TE":                       {"trailers"},
-		"Update-Insecure-Requests": {"1"},
-		"User-Agent":               {userAgent},
-	}
-
-	response, err := client.Do(request)
-	if err != nil {
-		return "", time.Time{}, err
+// set replaces the backend of the given type.
+func (b *Backends) set(backend SupportedBackend, be Backend) {
+	switch backend {
+	case YFinBackend:
+		b.YFin = be
+	case BATSBackend:
+		b.Bats = be
 	}
-	defer response.Body.Close()
-
-	var result string
-	// create a variable expiry that is ten years in the future
-	var expiry = time.Now().AddDate(10, 0, 0)
-
-	for _, cookie := range response.Cookies() {
-
-		if cookie.MaxAge <= 0 {
-			continue
-		}
-
-		cookieExpiry := time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+}
 
-		if cookie.Name != "AS" {
-			result += cookie.Name + "=" + cookie.Value + "; "
-			// set expiry to the latest cookie expiry if smaller than the current expiry
-			if cookie.Expires.Before(cookieExpiry) {
-				expiry = cookieExpiry
-			}
-		}
-	}
-	result = strings.TrimSuffix(result, "; ")
-	return result, expiry, nil
+// Call is the Backend.Call implementation for invoking market data APIs, using the Yahoo specialization.
+// If the API rejects the session, it is replaced and the call is made once more.
+func (s *yahooConfiguration) Call(path string, form *form.Values, ctx *context.Context, v interface{}) error {
+	return s.retrySession("GET", path, form, nil, ctx, v)
 }
 
-func fetchCrumb(cookies string) (string, error) {
-	client := http.Client{}
-	request, err := http.NewRequest("GET", crumbURL, nil)
+// Post is the PostBackend.Post implementation, using the Yahoo specialization.
+// Like Call, it replaces a rejected session once.
+func (s *yahooConfiguration) Post(path string, params *form.Values, body interface{}, ctx *context.Context, v interface{}) error {
+	data, err := json.Marshal(body)
 	if err != nil {
-		return "", err
-	}
-
-	request.Header = http.Header{
-		"Accept":          {"*/*"},
-		"Accept-Encoding": {"gzip, deflate, br"},
-		"Accept-Language": {"en-US,en;q=0.5"},
-		"Connection":      {"keep-alive"},
-		"Content-Type":    {"text/plain"},
-		"Cookie":          {cookies},
-		"Host":            {"query1.finance.yahoo.com"},
-		"Sec-Fetch-Dest":  {"empty"},
-		"Sec-Fetch-Mode":  {"cors"},
-		"Sec-Fetch-Site":  {"same-site"},
-		"TE":              {"trailers"},
-		"User-Agent":      {userAgent},
+		return CreateArgumentErrorS(fmt.Sprintf("cannot encode request body: %v", err))
 	}
+	return s.retrySession("POST", path, params, data, ctx, v)
+}
 
-	response, err := client.Do(request)
-	if err != nil {
-		return "", err
+// retrySession makes a call, and makes it once more
+// with a new session if the API rejects the current one.
+func (s *yahooConfiguration) retrySession(method, path string, form *form.Values, body []byte, ctx *context.Context, v interface{}) error {
+	c := context.Background()
+	if ctx != nil {
+		c = *ctx
 	}
-	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
+	session, err := s.session(c, nil)
 	if err != nil {
-		return "", err
+		return CreateRemoteError(err)
 	}
 
-	return string(body[:]), nil
-}
-
-func (s *yahooConfiguration) refreshCrumb() error {
-	cookies, expiry, err := fetchCookies()
-	if err != nil {
+	err = s.call(method, path, form, body, ctx, v, session)
+	if !isSessionRejected(err) {
 		return err
 	}
 
-	crumb, err := fetchCrumb(cookies)
+	s.logf(2, "Session rejected by api, refreshing crumb\n")
+	session, err = s.session(c, session)
 	if err != nil {
-		return err
+		return CreateRemoteError(err)
 	}
 
-	s.crumb = crumb
-	s.expiry = expiry
-	s.cookies = cookies
-	return nil
+	return s.call(method, path, form, body, ctx, v, session)
 }
 
-// Call is the Backend.Call implementation for invoking market data APIs, using the Yahoo specialization
-func (s *yahooConfiguration) Call(path string, form *form.Values, ctx *context.Context, v interface{}) error {
-	// Check if the cookies have expired.
-	if s.expiry.Before(time.Now()) {
-		// Refresh the cookies and crumb.
-		err := s.refreshCrumb()
-		if err != nil {
-			return err
-		}
+// call makes a single call using the given session.
+func (s *yahooConfiguration) call(method, path string, form *form.Values, body []byte, ctx *context.Context, v interface{}, session *Session) error {
+	query := ""
+	if form != nil && !form.Empty() {
+		query = form.Encode()
 	}
-
-	if s.crumb != "" {
-		form.Add("crumb", s.crumb)
+	if session.Crumb != "" {
+		if query != "" {
+			query += "&"
+		}
+		query += "crumb=" + url.QueryEscape(session.Crumb)
 	}
-
-	if form != nil && !form.Empty() {
-		path += "?" + form.Encode()
+	if query != "" {
+		path += "?" + query
 	}
 
-	req, err := s.newRequest("GET", path, ctx)
+	req, err := s.newRequest(method, path, body, ctx, session)
 	if err != nil {
 		return err
 	}
@@ -302,12 +331,26 @@ func (s *yahooConfiguration) Call(path string, form *form.Values, ctx *context.C
 
 // Call is the Backend.Call implementation for invoking market data APIs.
 func (s *BackendConfiguration) Call(path string, form *form.Values, ctx *context.Context, v interface{}) error {
+	return s.call("GET", path, form, nil, ctx, v)
+}
+
+// Post is the PostBackend.Post implementation for invoking market data APIs.
+func (s *BackendConfiguration) Post(path string, params *form.Values, body interface{}, ctx *context.Context, v interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return CreateArgumentErrorS(fmt.Sprintf("cannot encode request body: %v", err))
+	}
+	return s.call("POST", path, params, data, ctx, v)
+}
+
+// call makes a single call.
+func (s *BackendConfiguration) call(method, path string, form *form.Values, body []byte, ctx *context.Context, v interface{}) error {
 
 	if form != nil && !form.Empty() {
 		path += "?" + form.Encode()
 	}
 
-	req, err := s.newRequest("GET", path, ctx)
+	req, err := s.newRequest(method, path, body, ctx)
 	if err != nil {
 		return err
 	}
@@ -319,8 +362,8 @@ func (s *BackendConfiguration) Call(path string, form *form.Values, ctx *context
 	return nil
 }
 
-func (s *yahooConfiguration) newRequest(method, path string, ctx *context.Context) (*http.Request, error) {
-	req, err := s.BackendConfiguration.newRequest(method, path, ctx)
+func (s *yahooConfiguration) newRequest(method, path string, body []byte, ctx *context.Context, session *Session) (*http.Request, error) {
+	req, err := s.BackendConfiguration.newRequest(method, path, body, ctx)
 
 	if err != nil {
 		return nil, err
@@ -331,7 +374,7 @@ func (s *yahooConfiguration) newRequest(method, path string, ctx *context.Contex
 		"Accept-Language": {"en-US,en;q=0.5"},
 		"Connection":      {"keep-alive"},
 		"Content-Type":    {"application/json"},
-		"Cookie":          {s.cookies},
+		"Cookie":          {session.Cookies},
 		"Host":            {"query1.finance.yahoo.com"},
 		"Origin":          {"https://finance.yahoo.com"},
 		"Referer":         {"https://finance.yahoo.com"},
@@ -345,20 +388,25 @@ func (s *yahooConfiguration) newRequest(method, path string, ctx *context.Contex
 	return req, nil
 }
 
-func (s *BackendConfiguration) newRequest(method, path string, ctx *context.Context) (*http.Request, error) {
+func (s *BackendConfiguration) newRequest(method, path string, body []byte, ctx *context.Context) (*http.Request, error) {
 	if !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
 
 	path = s.URL + path
 
-	req, err := http.NewRequest(method, path, nil)
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, path, r)
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Cannot create api request: %v\n", err)
-		}
+		s.logf(1, "Cannot create api request: %v\n", err)
 		return nil, err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	if ctx != nil {
 		req = req.WithContext(*ctx)
 	}
@@ -368,50 +416,111 @@ func (s *BackendConfiguration) newRequest(method, path string, ctx *context.Cont
 
 // do is used by Call to execute an API request and parse the response. It uses
 // the backend's HTTP client to execute the request and unmarshals the response
-// into v. It also handles unmarshaling errors returned by the API.
+// into v. Every attempt waits on the backend's rate limiter first, and
+// transient failures are retried according to the backend's retry
+// policy, for as long as the request context allows.
 func (s *BackendConfiguration) do(req *http.Request, v interface{}) error {
-	if LogLevel > 1 {
-		Logger.Printf("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
+	attempts := s.Retry.attempts()
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var (
+			resBody   []byte
+			wait      time.Duration
+			retryable bool
+		)
+
+		// A retried request sends its body again.
+		if attempt > 1 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return err
+			}
+		}
+
+		if err = s.Limiter.Wait(req.Context(), req.URL.Host); err != nil {
+			s.logf(1, "Rate limited request abandoned: %v\n", err)
+			return err
+		}
+
+		resBody, wait, retryable, err = s.send(req)
+		if err == nil {
+			if v != nil {
+				if err = json.Unmarshal(resBody, v); err != nil {
+					return CreateDecodeError(err, resBody)
+				}
+			}
+			return nil
+		}
+
+		if !retryable || attempt == attempts {
+			break
+		}
+		if s.Retry.waitTooLong(wait) {
+			s.logf(2, "Not retrying %v %v%v, server asked to wait %v\n", req.Method, req.URL.Host, req.URL.Path, wait)
+			break
+		}
+
+		if backoff := s.Retry.backoff(attempt); backoff > wait {
+			wait = backoff
+		}
+		s.logf(2, "Retrying %v %v%v in %v (attempt %d of %d)\n", req.Method, req.URL.Host, req.URL.Path, wait, attempt+1, attempts)
+		if !sleepCtx(req.Context(), wait) {
+			break
+		}
 	}
 
+	return err
+}
+
+// send performs a single attempt of an API request. It returns the response
+// body on success. On failure, it reports whether the error is retryable and
+// how long the server asked the client to wait before trying again.
+func (s *BackendConfiguration) send(req *http.Request) (resBody []byte, wait time.Duration, retryable bool, err error) {
+	s.logf(2, "Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
+
 	start := time.Now()
 
 	res, err := s.HTTPClient.Do(req)
 
-	if LogLevel > 2 {
-		Logger.Printf("Completed in %v\n", time.Since(start))
-	}
+	s.logf(3, "Completed in %v\n", time.Since(start))
 
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Request to api failed: %v\n", err)
-		}
-		return err
+		s.logf(1, "Request to api failed: %v\n", err)
+		retryable = s.Retry != nil && s.Retry.retryableError(err)
+		return nil, 0, retryable, CreateRemoteError(err)
 	}
 	defer res.Body.Close()
 
-	resBody, err := io.ReadAll(res.Body)
+	resBody, err = io.ReadAll(res.Body)
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Cannot parse response: %v\n", err)
-		}
-		return err
+		s.logf(1, "Cannot parse response: %v\n", err)
+		retryable = s.Retry != nil && s.Retry.retryableError(err)
+		return nil, 0, retryable, CreateRemoteError(err)
 	}
 
 	if res.StatusCode >= 400 {
-		if LogLevel > 0 {
-			Logger.Printf("API error: %q\n", resBody)
+		s.logf(1, "API error: %q\n", resBody)
+		if s.Retry != nil && s.Retry.retryableStatus(res.StatusCode) {
+			retryable = true
+			wait, _ = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
 		}
-		return CreateRemoteErrorS("error response recieved from upstream api")
+		return nil, wait, retryable, createHTTPError(res.StatusCode, req.URL, resBody)
 	}
 
-	if LogLevel > 2 {
-		Logger.Printf("API response: %q\n", resBody)
-	}
+	s.logf(3, "API response: %q\n", resBody)
 
-	if v != nil {
-		return json.Unmarshal(resBody, v)
-	}
+	return resBody, 0, false, nil
+}
 
-	return nil
+// logf writes to the backend's logger when level is enabled.
+// Levels follow LogLevel: 1 for errors, 2 for
+// informational and 3 for debug messages.
+func (s *BackendConfiguration) logf(level int, format string, v ...interface{}) {
+	logger, max := s.Logger, s.LogLevel
+	if logger == nil {
+		logger, max = Logger, LogLevel
+	}
+	if logger != nil && max >= level {
+		logger.Printf(format, v...)
+	}
 }
@@ -25,19 +25,22 @@ type Params struct {
 	// quote is requested.
 	Symbols []string `form:"-"`
 	sym     string   `form:"symbols"`
+	// Batching splits long lists of symbols
+	// across several requests.
+	finance.Batching `form:"-"`
 }
 
 // Iter is an iterator for a list of quotes.
-// The embedded Iter carries methods with it;
+// The embedded iter.Of carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*iter.Iter
+	*iter.Of[*finance.ForexPair]
 }
 
 // ForexPair returns the most recent ForexPair
 // visited by a call to Next.
 func (i *Iter) ForexPair() *finance.ForexPair {
-	return i.Current().(*finance.ForexPair)
+	return i.Current()
 }
 
 // Get returns an ForexPair quote that matches the parameters specified.
@@ -65,38 +68,37 @@ func ListP(params *Params) *Iter {
 // ListP returns a quote iterator.
 func (c Client) ListP(params *Params) *Iter {
 
-	if params.Context == nil {
-		ctx := context.TODO()
-		params.Context = &ctx
-	}
-
 	// Validate input.
 	// TODO: validate symbols..
 	if params == nil || len(params.Symbols) == 0 {
-		return &Iter{iter.NewE(finance.CreateArgumentError())}
+		return &Iter{iter.NewOfE[*finance.ForexPair](finance.CreateArgumentError())}
 	}
-	params.sym = strings.Join(params.Symbols, ",")
-
-	body := &form.Values{}
-	form.AppendTo(body, params)
 
-	return &Iter{iter.New(body, func(b *form.Values) (interface{}, []interface{}, error) {
-
-		resp := response{}
-		err := c.B.Call("/v7/finance/quote", body, params.Context, &resp)
-		if err != nil {
-			err = finance.CreateRemoteError(err)
-		}
-
-		ret := make([]interface{}, len(resp.Inner.Result))
-		for i, v := range resp.Inner.Result {
-			ret[i] = v
-		}
-		if resp.Inner.Error != nil {
-			err = finance.CreateRemoteError(resp.Inner.Error)
-		}
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
 
-		return nil, ret, err
+	return &Iter{iter.New(nil, func(*form.Values) (interface{}, []*finance.ForexPair, error) {
+		results, err := finance.Batch(params.Symbols, params.Batching, func(symbols []string) ([]*finance.ForexPair, error) {
+
+			p := *params
+			p.sym = strings.Join(symbols, ",")
+			body := &form.Values{}
+			form.AppendTo(body, &p)
+
+			resp := response{}
+			err := c.B.Call("/v7/finance/quote", body, params.Context, &resp)
+			if err != nil {
+				return nil, finance.CreateRemoteError(err)
+			}
+			if resp.Inner.Error != nil {
+				return nil, resp.Inner.Error
+			}
+
+			return resp.Inner.Result, nil
+		})
+		return nil, results, err
 	})}
 }
 
@@ -0,0 +1,62 @@
+package finance
+
+import "time"
+
+// FundProfile is the profile, portfolio and
+// performance of an etf or mutual fund.
+type FundProfile struct {
+	Symbol        string
+	Name          string
+	Family        string
+	Category      string
+	LegalType     string
+	InceptionDate time.Time
+	ExpenseRatio  Value
+	Turnover      Value
+	TotalAssets   Value
+	Yield         Value
+	// Holdings are the top holdings, by weight.
+	Holdings []*FundHolding
+	// Allocation is the weight of each asset class.
+	Allocation FundAllocation
+	// SectorWeightings maps sectors, such as technology
+	// or consumer_cyclical, to their weight.
+	SectorWeightings map[string]Value
+	// BondRatings maps credit ratings, such as aaa or
+	// below_b, to the weight of the bonds they rate.
+	BondRatings map[string]Value
+	Performance FundPerformance
+}
+
+// FundHolding is a holding of a fund.
+type FundHolding struct {
+	Symbol string
+	Name   string
+	Weight Value
+}
+
+// FundAllocation is the weight of
+// each asset class in a fund.
+type FundAllocation struct {
+	Stock       Value
+	Bond        Value
+	Cash        Value
+	Preferred   Value
+	Convertible Value
+	Other       Value
+}
+
+// FundPerformance is the total return of
+// a fund over the periods ending on AsOf.
+type FundPerformance struct {
+	AsOf       time.Time
+	YTD        Value
+	OneMonth   Value
+	ThreeMonth Value
+	OneYear    Value
+	ThreeYear  Value
+	FiveYear   Value
+	TenYear    Value
+	// Annual maps calendar years to their return.
+	Annual map[int]Value
+}
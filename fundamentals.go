@@ -0,0 +1,27 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LineItem names a line of a financial statement, such as
+// "TotalRevenue". Names are the same for every frequency.
+type LineItem string
+
+// FinancialStatement is a financial statement
+// of a single fiscal period.
+type FinancialStatement struct {
+	Symbol string
+	// Frequency is annual, quarterly or trailing.
+	Frequency string
+	// Date is the last day of the period.
+	Date time.Time
+	// PeriodType is the length of the period,
+	// such as 12M, 3M or TTM.
+	PeriodType string
+	Currency   string
+	// Items holds the reported value of each line item.
+	Items map[LineItem]decimal.Decimal
+}
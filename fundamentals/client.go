@@ -0,0 +1,285 @@
+// Package fundamentals fetches the income statements, balance sheets
+// and cash flow statements of a company from the yahoo fundamentals
+// timeseries, which reports every frequency under the same line items.
+package fundamentals
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/iter"
+	"github.com/shopspring/decimal"
+)
+
+// Statement is a kind of financial statement.
+type Statement int
+
+const (
+	// IncomeStatement reports revenue, expenses and earnings.
+	IncomeStatement Statement = iota
+	// BalanceSheet reports assets, liabilities and equity.
+	BalanceSheet
+	// CashFlow reports operating, investing and financing cash flows.
+	CashFlow
+)
+
+// items returns the default line items of s.
+func (s Statement) items() []finance.LineItem {
+	switch s {
+	case BalanceSheet:
+		return BalanceSheetItems
+	case CashFlow:
+		return CashFlowItems
+	}
+	return IncomeStatementItems
+}
+
+// Frequency is the length of the periods of a statement.
+type Frequency string
+
+const (
+	// Annual statements cover fiscal years.
+	Annual Frequency = "annual"
+	// Quarterly statements cover fiscal quarters.
+	Quarterly Frequency = "quarterly"
+	// Trailing statements cover the trailing twelve months.
+	// Balance sheets are not reported for them.
+	Trailing Frequency = "trailing"
+)
+
+// epoch is the default start of a request,
+// before any statement yahoo reports.
+var epoch = time.Date(1985, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Client is used to invoke fundamentals APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and statement information.
+type Params struct {
+	finance.Params `form:"-"`
+	// Symbol is the company reporting the statements.
+	Symbol string `form:"-"`
+	// Statement is the kind of statement requested.
+	Statement Statement `form:"-"`
+	// Frequency is the length of the periods, Annual if empty.
+	Frequency Frequency `form:"-"`
+	// Items, if set, replaces the default line items
+	// of the statement.
+	Items []finance.LineItem `form:"-"`
+	// Start and End, if set, bound the period end dates.
+	Start *datetime.Datetime `form:"-"`
+	End   *datetime.Datetime `form:"-"`
+
+	symbol  string `form:"symbol"`
+	types   string `form:"type"`
+	period1 int    `form:"period1"`
+	period2 int    `form:"period2"`
+}
+
+// Iter is an iterator for a list of statements,
+// in increasing order of their period.
+// The embedded iter.Of carries methods with it;
+// see its documentation for details.
+type Iter struct {
+	*iter.Of[*finance.FinancialStatement]
+}
+
+// Statement returns the most recent statement
+// visited by a call to Next.
+func (i *Iter) Statement() *finance.FinancialStatement {
+	return i.Current()
+}
+
+// Table returns the remaining statements of i pivoted
+// into a table, along with the error of i.
+func (i *Iter) Table() (*Table, error) {
+	statements, err := iter.Collect(i.Of)
+	return Pivot(statements), err
+}
+
+// IncomeStatements returns the income statements of a symbol.
+func IncomeStatements(symbol string, f Frequency) *Iter {
+	return Get(&Params{Symbol: symbol, Statement: IncomeStatement, Frequency: f})
+}
+
+// BalanceSheets returns the balance sheets of a symbol.
+func BalanceSheets(symbol string, f Frequency) *Iter {
+	return Get(&Params{Symbol: symbol, Statement: BalanceSheet, Frequency: f})
+}
+
+// CashFlows returns the cash flow statements of a symbol.
+func CashFlows(symbol string, f Frequency) *Iter {
+	return Get(&Params{Symbol: symbol, Statement: CashFlow, Frequency: f})
+}
+
+// Get returns a statement iterator
+// and requires a params struct as an argument.
+func Get(params *Params) *Iter {
+	return getC().Get(params)
+}
+
+// Get returns a statement iterator.
+func (c Client) Get(params *Params) *Iter {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return &Iter{iter.NewOfE[*finance.FinancialStatement](finance.CreateArgumentError())}
+	}
+
+	frequency := params.Frequency
+	if frequency == "" {
+		frequency = Annual
+	}
+	switch {
+	case frequency != Annual && frequency != Quarterly && frequency != Trailing:
+		return &Iter{iter.NewOfE[*finance.FinancialStatement](finance.CreateArgumentErrorS("unknown frequency " + string(frequency)))}
+	case frequency == Trailing && params.Statement == BalanceSheet:
+		return &Iter{iter.NewOfE[*finance.FinancialStatement](finance.CreateArgumentErrorS("balance sheets have no trailing frequency"))}
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	items := params.Items
+	if len(items) == 0 {
+		items = params.Statement.items()
+	}
+	types := make([]string, len(items))
+	for i, item := range items {
+		types[i] = string(frequency) + string(item)
+	}
+
+	params.symbol = params.Symbol
+	params.types = strings.Join(types, ",")
+	params.period1 = int(epoch.Unix())
+	if params.Start != nil {
+		params.period1 = params.Start.Unix()
+	}
+	params.period2 = int(time.Now().Unix())
+	if params.End != nil {
+		params.period2 = int(params.End.SessionEnd().Unix())
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	return &Iter{iter.New(body, func(b *form.Values) (interface{}, []*finance.FinancialStatement, error) {
+
+		resp := response{}
+		err := c.B.Call("/ws/fundamentals-timeseries/v1/finance/timeseries/"+params.Symbol, b, params.Context, &resp)
+		if err != nil {
+			return nil, nil, finance.CreateRemoteError(err)
+		}
+		if resp.Inner.Error != nil {
+			return nil, nil, resp.Inner.Error
+		}
+
+		statements, err := parse(params.Symbol, frequency, resp.Inner.Result)
+		return nil, statements, err
+	})}
+}
+
+// parse groups the points of each series into
+// one statement per period.
+func parse(symbol string, frequency Frequency, results []json.RawMessage) ([]*finance.FinancialStatement, error) {
+	byDate := map[string]*finance.FinancialStatement{}
+	for _, raw := range results {
+		var s series
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, finance.CreateDecodeError(err, raw)
+		}
+		if len(s.Meta.Type) == 0 {
+			continue
+		}
+		name := s.Meta.Type[0]
+		item := finance.LineItem(strings.TrimPrefix(name, string(frequency)))
+
+		var points []*point
+		if data, ok := s.Data[name]; ok {
+			if err := json.Unmarshal(data, &points); err != nil {
+				return nil, finance.CreateDecodeError(err, data)
+			}
+		}
+		for _, p := range points {
+			if p == nil || !p.ReportedValue.Valid {
+				continue
+			}
+			st, ok := byDate[p.AsOfDate]
+			if !ok {
+				date, err := time.Parse("2006-01-02", p.AsOfDate)
+				if err != nil {
+					return nil, finance.CreateDecodeError(err, raw)
+				}
+				st = &finance.FinancialStatement{
+					Symbol:     symbol,
+					Frequency:  string(frequency),
+					Date:       date,
+					PeriodType: p.PeriodType,
+					Currency:   p.CurrencyCode,
+					Items:      map[finance.LineItem]decimal.Decimal{},
+				}
+				byDate[p.AsOfDate] = st
+			}
+			st.Items[item] = p.ReportedValue.Decimal()
+		}
+	}
+
+	statements := make([]*finance.FinancialStatement, 0, len(byDate))
+	for _, st := range byDate {
+		statements = append(statements, st)
+	}
+	sort.Slice(statements, func(i, j int) bool {
+		return statements[i].Date.Before(statements[j].Date)
+	})
+	return statements, nil
+}
+
+// response is a yfin timeseries response.
+type response struct {
+	Inner struct {
+		Result []json.RawMessage  `json:"result"`
+		Error  *finance.YfinError `json:"error"`
+	} `json:"timeseries"`
+}
+
+// series is the result for a single type. Its points
+// are held under a key named after the type.
+type series struct {
+	Meta struct {
+		Symbol []string `json:"symbol"`
+		Type   []string `json:"type"`
+	} `json:"meta"`
+	Data map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *series) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.Data); err != nil {
+		return err
+	}
+	if meta, ok := s.Data["meta"]; ok {
+		return json.Unmarshal(meta, &s.Meta)
+	}
+	return nil
+}
+
+// point is the value of a line item for one period.
+type point struct {
+	AsOfDate      string        `json:"asOfDate"`
+	PeriodType    string        `json:"periodType"`
+	CurrencyCode  string        `json:"currencyCode"`
+	ReportedValue finance.Value `json:"reportedValue"`
+}
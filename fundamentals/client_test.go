@@ -0,0 +1,141 @@
+package fundamentals
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("fundamentals are only served by the in-process server")
+	}
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIncomeStatements(t *testing.T) {
+	skipMock(t)
+
+	i := IncomeStatements(tests.TestEquitySymbol, Annual)
+	var dates []time.Time
+	for i.Next() {
+		dates = append(dates, i.Statement().Date)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []time.Time{date(2020, 9, 30), date(2021, 9, 30), date(2022, 9, 30), date(2023, 9, 30)}, dates)
+
+	latest := i.Statement()
+	assert.Equal(t, tests.TestEquitySymbol, latest.Symbol)
+	assert.Equal(t, "annual", latest.Frequency)
+	assert.Equal(t, "12M", latest.PeriodType)
+	assert.Equal(t, "USD", latest.Currency)
+	assert.Equal(t, "383285000000", latest.Items["TotalRevenue"].String())
+	assert.Equal(t, "6.13", latest.Items["DilutedEPS"].String())
+	assert.Len(t, latest.Items, 4)
+}
+
+func TestFrequencies(t *testing.T) {
+	skipMock(t)
+
+	// Every frequency reports under the same line items.
+	for f, n := range map[Frequency]int{Annual: 4, Quarterly: 4, Trailing: 1} {
+		i := IncomeStatements(tests.TestEquitySymbol, f)
+		count := 0
+		for i.Next() {
+			count++
+			assert.Equal(t, string(f), i.Statement().Frequency)
+			assert.Contains(t, i.Statement().Items, finance.LineItem("TotalRevenue"))
+			assert.Contains(t, i.Statement().Items, finance.LineItem("NetIncome"))
+		}
+		assert.Nil(t, i.Err())
+		assert.Equal(t, n, count, string(f))
+	}
+
+	i := CashFlows(tests.TestEquitySymbol, Trailing)
+	assert.True(t, i.Next())
+	assert.Equal(t, "TTM", i.Statement().PeriodType)
+	assert.Equal(t, "99584000000", i.Statement().Items["FreeCashFlow"].String())
+
+	i = BalanceSheets(tests.TestEquitySymbol, Quarterly)
+	assert.True(t, i.Next())
+	assert.Equal(t, date(2022, 12, 31), i.Statement().Date)
+}
+
+func TestStatementParams(t *testing.T) {
+	skipMock(t)
+
+	i := Get(&Params{
+		Symbol:    tests.TestEquitySymbol,
+		Statement: BalanceSheet,
+		Items:     []finance.LineItem{"StockholdersEquity"},
+		Start:     datetime.Date(2021, time.January, 1, nil),
+		End:       datetime.Date(2022, time.September, 30, nil),
+	})
+	var got []string
+	for i.Next() {
+		assert.Len(t, i.Statement().Items, 1)
+		got = append(got, i.Statement().Items["StockholdersEquity"].String())
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []string{"63090000000", "50672000000"}, got)
+}
+
+func TestTable(t *testing.T) {
+	skipMock(t)
+
+	table, err := IncomeStatements(tests.TestEquitySymbol, Annual).Table()
+	assert.Nil(t, err)
+	assert.Len(t, table.Periods, 4)
+	assert.ElementsMatch(t, []finance.LineItem{"TotalRevenue", "NetIncome", "DilutedEPS", "GrossProfit"}, table.Items)
+
+	eps := table.Row("DilutedEPS")
+	assert.Len(t, eps, 4)
+	assert.False(t, eps[0].Valid)
+	assert.True(t, eps[1].Valid)
+	assert.Equal(t, "5.61", eps[1].Decimal.String())
+
+	v, ok := table.Get("NetIncome", date(2022, 9, 30))
+	assert.True(t, ok)
+	assert.Equal(t, "99803000000", v.String())
+	_, ok = table.Get("NetIncome", date(2019, 9, 30))
+	assert.False(t, ok)
+	assert.Len(t, table.Row("Unknown"), 4)
+}
+
+func TestPivot(t *testing.T) {
+	one, two := date(2022, 12, 31), date(2023, 3, 31)
+	d := decimal.RequireFromString
+	table := Pivot([]*finance.FinancialStatement{
+		{Date: two, Items: map[finance.LineItem]decimal.Decimal{"TotalRevenue": d("2"), "Inventory": d("5")}},
+		{Date: one, Items: map[finance.LineItem]decimal.Decimal{"TotalRevenue": d("1")}},
+		{Date: two, Items: map[finance.LineItem]decimal.Decimal{"TotalAssets": d("9")}},
+	})
+	assert.Equal(t, []time.Time{one, two}, table.Periods)
+	assert.Equal(t, []finance.LineItem{"TotalRevenue", "Inventory", "TotalAssets"}, table.Items)
+	assert.False(t, table.Row("Inventory")[0].Valid)
+	assert.Equal(t, "5", table.Row("Inventory")[1].Decimal.String())
+	assert.Equal(t, "9", table.Row("TotalAssets")[1].Decimal.String())
+}
+
+func TestBadParamsStatements(t *testing.T) {
+	i := Get(nil)
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+
+	i = BalanceSheets(tests.TestEquitySymbol, Trailing)
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+
+	i = IncomeStatements(tests.TestEquitySymbol, "weekly")
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
@@ -0,0 +1,89 @@
+package fundamentals
+
+import finance "github.com/piquette/finance-go"
+
+// IncomeStatementItems are the line items
+// requested for income statements by default.
+var IncomeStatementItems = []finance.LineItem{
+	"TotalRevenue",
+	"OperatingRevenue",
+	"CostOfRevenue",
+	"GrossProfit",
+	"ResearchAndDevelopment",
+	"SellingGeneralAndAdministration",
+	"OperatingExpense",
+	"TotalExpenses",
+	"OperatingIncome",
+	"InterestIncome",
+	"InterestExpense",
+	"NetInterestIncome",
+	"OtherIncomeExpense",
+	"PretaxIncome",
+	"TaxProvision",
+	"NetIncome",
+	"NetIncomeCommonStockholders",
+	"BasicEPS",
+	"DilutedEPS",
+	"BasicAverageShares",
+	"DilutedAverageShares",
+	"EBIT",
+	"EBITDA",
+	"NormalizedEBITDA",
+	"ReconciledDepreciation",
+}
+
+// BalanceSheetItems are the line items
+// requested for balance sheets by default.
+var BalanceSheetItems = []finance.LineItem{
+	"TotalAssets",
+	"CurrentAssets",
+	"CashAndCashEquivalents",
+	"CashCashEquivalentsAndShortTermInvestments",
+	"AccountsReceivable",
+	"Inventory",
+	"TotalNonCurrentAssets",
+	"NetPPE",
+	"Goodwill",
+	"TotalLiabilitiesNetMinorityInterest",
+	"CurrentLiabilities",
+	"AccountsPayable",
+	"CurrentDebt",
+	"LongTermDebt",
+	"TotalDebt",
+	"NetDebt",
+	"StockholdersEquity",
+	"TotalEquityGrossMinorityInterest",
+	"RetainedEarnings",
+	"CommonStock",
+	"WorkingCapital",
+	"TangibleBookValue",
+	"InvestedCapital",
+	"ShareIssued",
+	"OrdinarySharesNumber",
+	"TreasurySharesNumber",
+}
+
+// CashFlowItems are the line items
+// requested for cash flow statements by default.
+var CashFlowItems = []finance.LineItem{
+	"OperatingCashFlow",
+	"InvestingCashFlow",
+	"FinancingCashFlow",
+	"BeginningCashPosition",
+	"EndCashPosition",
+	"ChangesInCash",
+	"NetIncomeFromContinuingOperations",
+	"DepreciationAndAmortization",
+	"StockBasedCompensation",
+	"ChangeInWorkingCapital",
+	"CapitalExpenditure",
+	"FreeCashFlow",
+	"PurchaseOfInvestment",
+	"SaleOfInvestment",
+	"RepurchaseOfCapitalStock",
+	"CashDividendsPaid",
+	"IssuanceOfDebt",
+	"RepaymentOfDebt",
+	"IncomeTaxPaidSupplementalData",
+	"InterestPaidSupplementalData",
+}
@@ -0,0 +1,85 @@
+package fundamentals
+
+import (
+	"sort"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/shopspring/decimal"
+)
+
+// Table holds statements pivoted into a row for each line
+// item, with a column for each period in increasing order.
+type Table struct {
+	// Periods are the end dates of the columns.
+	Periods []time.Time
+	// Items are the line items of the rows,
+	// in the order they were first reported.
+	Items []finance.LineItem
+	rows  map[finance.LineItem][]decimal.NullDecimal
+}
+
+// Pivot returns statements as a table keyed by line item.
+// Statements ending on the same date share a column.
+func Pivot(statements []*finance.FinancialStatement) *Table {
+	t := &Table{rows: map[finance.LineItem][]decimal.NullDecimal{}}
+
+	sorted := make([]*finance.FinancialStatement, len(statements))
+	copy(sorted, statements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	for _, st := range sorted {
+		col := len(t.Periods) - 1
+		if col < 0 || !t.Periods[col].Equal(st.Date) {
+			t.Periods = append(t.Periods, st.Date)
+			col++
+			for item, row := range t.rows {
+				t.rows[item] = append(row, decimal.NullDecimal{})
+			}
+		}
+		for _, item := range itemsOf(st) {
+			row, ok := t.rows[item]
+			if !ok {
+				row = make([]decimal.NullDecimal, len(t.Periods))
+				t.Items = append(t.Items, item)
+			}
+			row[col] = decimal.NullDecimal{Decimal: st.Items[item], Valid: true}
+			t.rows[item] = row
+		}
+	}
+	return t
+}
+
+// itemsOf returns the line items of st in a stable order.
+func itemsOf(st *finance.FinancialStatement) []finance.LineItem {
+	items := make([]finance.LineItem, 0, len(st.Items))
+	for item := range st.Items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i] < items[j] })
+	return items
+}
+
+// Row returns the values of item for each period,
+// invalid where the period does not report it.
+func (t *Table) Row(item finance.LineItem) []decimal.NullDecimal {
+	row, ok := t.rows[item]
+	if !ok {
+		return make([]decimal.NullDecimal, len(t.Periods))
+	}
+	return row
+}
+
+// Get returns the value of item for the period
+// ending on date, and whether it was reported.
+func (t *Table) Get(item finance.LineItem, date time.Time) (decimal.Decimal, bool) {
+	for col, p := range t.Periods {
+		if p.Equal(date) {
+			v := t.Row(item)[col]
+			return v.Decimal, v.Valid
+		}
+	}
+	return decimal.Decimal{}, false
+}
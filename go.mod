@@ -1,6 +1,6 @@
 module github.com/piquette/finance-go
 
-go 1.13
+go 1.18
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,53 @@
+package finance
+
+import "time"
+
+// MajorHolders is the breakdown of the ownership of a company.
+type MajorHolders struct {
+	InsidersPercentHeld          Value `json:"insidersPercentHeld"`
+	InstitutionsPercentHeld      Value `json:"institutionsPercentHeld"`
+	InstitutionsFloatPercentHeld Value `json:"institutionsFloatPercentHeld"`
+	InstitutionsCount            Value `json:"institutionsCount"`
+}
+
+// Holder is an institution or fund holding shares of a company,
+// as of its latest report.
+type Holder struct {
+	Name          string
+	Shares        Value
+	Value         Value
+	PercentHeld   Value
+	PercentChange Value
+	ReportDate    time.Time
+}
+
+// Insider is an insider of a company and their holdings.
+type Insider struct {
+	Name     string
+	Relation string
+	URL      string
+	// LatestTransaction describes the latest transaction,
+	// such as Sale or Stock Gift.
+	LatestTransaction     string
+	LatestTransactionDate time.Time
+	PositionDirect        Value
+	PositionDirectDate    time.Time
+	PositionIndirect      Value
+	PositionIndirectDate  time.Time
+}
+
+// InsiderTransaction is a transaction in the
+// shares of a company by one of its insiders.
+type InsiderTransaction struct {
+	Name     string
+	Relation string
+	URL      string
+	// Text describes the transaction, such as
+	// "Sale at price 188.93 - 190.20 per share."
+	Text   string
+	Shares Value
+	Value  Value
+	Date   time.Time
+	// Ownership is D for direct or I for indirect.
+	Ownership string
+}
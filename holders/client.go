@@ -0,0 +1,284 @@
+// Package holders fetches the ownership of a company: the breakdown
+// of its major holders, its top institutional and fund holders,
+// its insiders and their transactions.
+package holders
+
+import (
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/iter"
+	"github.com/piquette/finance-go/summary"
+)
+
+// Client is used to invoke holders APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	finance.Params `form:"-"`
+	// Symbol is the company held.
+	Symbol string `form:"-"`
+}
+
+// Iter is an iterator for a list of holders.
+// The embedded iter.Of carries methods with it;
+// see its documentation for details.
+type Iter struct {
+	*iter.Of[*finance.Holder]
+}
+
+// Holder returns the most recent holder
+// visited by a call to Next.
+func (i *Iter) Holder() *finance.Holder {
+	return i.Current()
+}
+
+// InsiderIter is an iterator for a list of insiders.
+type InsiderIter struct {
+	*iter.Of[*finance.Insider]
+}
+
+// Insider returns the most recent insider
+// visited by a call to Next.
+func (i *InsiderIter) Insider() *finance.Insider {
+	return i.Current()
+}
+
+// TransactionIter is an iterator for a list of insider transactions.
+type TransactionIter struct {
+	*iter.Of[*finance.InsiderTransaction]
+}
+
+// Transaction returns the most recent transaction
+// visited by a call to Next.
+func (i *TransactionIter) Transaction() *finance.InsiderTransaction {
+	return i.Current()
+}
+
+// Major returns the breakdown of the major holders of a symbol.
+func Major(symbol string) (*finance.MajorHolders, error) {
+	return MajorP(&Params{Symbol: symbol})
+}
+
+// MajorP returns the breakdown of the major holders
+// and requires a params struct as an argument.
+func MajorP(params *Params) (*finance.MajorHolders, error) {
+	return getC().MajorP(params)
+}
+
+// MajorP returns the breakdown of the major holders.
+func (c Client) MajorP(params *Params) (*finance.MajorHolders, error) {
+	r := result{}
+	if err := c.decode(params, summary.MajorHoldersBreakdown, &r); err != nil {
+		return nil, err
+	}
+	if r.MajorHoldersBreakdown == nil {
+		return nil, finance.CreateNotFoundError(params.Symbol)
+	}
+	return r.MajorHoldersBreakdown, nil
+}
+
+// Institutions returns the top institutional holders of a symbol.
+func Institutions(symbol string) *Iter {
+	return InstitutionsP(&Params{Symbol: symbol})
+}
+
+// InstitutionsP returns the top institutional holders
+// and requires a params struct as an argument.
+func InstitutionsP(params *Params) *Iter {
+	return getC().InstitutionsP(params)
+}
+
+// InstitutionsP returns the top institutional holders.
+func (c Client) InstitutionsP(params *Params) *Iter {
+	return &Iter{list(c, params, summary.InstitutionOwnership, func(r *result) []*finance.Holder {
+		return r.InstitutionOwnership.holders()
+	})}
+}
+
+// Funds returns the top mutual fund holders of a symbol.
+func Funds(symbol string) *Iter {
+	return FundsP(&Params{Symbol: symbol})
+}
+
+// FundsP returns the top mutual fund holders
+// and requires a params struct as an argument.
+func FundsP(params *Params) *Iter {
+	return getC().FundsP(params)
+}
+
+// FundsP returns the top mutual fund holders.
+func (c Client) FundsP(params *Params) *Iter {
+	return &Iter{list(c, params, summary.FundOwnership, func(r *result) []*finance.Holder {
+		return r.FundOwnership.holders()
+	})}
+}
+
+// Insiders returns the insider roster of a symbol.
+func Insiders(symbol string) *InsiderIter {
+	return InsidersP(&Params{Symbol: symbol})
+}
+
+// InsidersP returns the insider roster
+// and requires a params struct as an argument.
+func InsidersP(params *Params) *InsiderIter {
+	return getC().InsidersP(params)
+}
+
+// InsidersP returns the insider roster.
+func (c Client) InsidersP(params *Params) *InsiderIter {
+	return &InsiderIter{list(c, params, summary.InsiderHolders, func(r *result) []*finance.Insider {
+		if r.InsiderHolders == nil {
+			return nil
+		}
+		var insiders []*finance.Insider
+		for _, h := range r.InsiderHolders.Holders {
+			if h == nil {
+				continue
+			}
+			insiders = append(insiders, &finance.Insider{
+				Name:                  h.Name,
+				Relation:              h.Relation,
+				URL:                   h.URL,
+				LatestTransaction:     h.TransactionDescription,
+				LatestTransactionDate: h.LatestTransDate.Time,
+				PositionDirect:        h.PositionDirect,
+				PositionDirectDate:    h.PositionDirectDate.Time,
+				PositionIndirect:      h.PositionIndirect,
+				PositionIndirectDate:  h.PositionIndirectDate.Time,
+			})
+		}
+		return insiders
+	})}
+}
+
+// InsiderTransactions returns the recent
+// insider transactions of a symbol.
+func InsiderTransactions(symbol string) *TransactionIter {
+	return InsiderTransactionsP(&Params{Symbol: symbol})
+}
+
+// InsiderTransactionsP returns the recent insider transactions
+// and requires a params struct as an argument.
+func InsiderTransactionsP(params *Params) *TransactionIter {
+	return getC().InsiderTransactionsP(params)
+}
+
+// InsiderTransactionsP returns the recent insider transactions.
+func (c Client) InsiderTransactionsP(params *Params) *TransactionIter {
+	return &TransactionIter{list(c, params, summary.InsiderTransactions, func(r *result) []*finance.InsiderTransaction {
+		if r.InsiderTransactions == nil {
+			return nil
+		}
+		var transactions []*finance.InsiderTransaction
+		for _, t := range r.InsiderTransactions.Transactions {
+			if t == nil {
+				continue
+			}
+			transactions = append(transactions, &finance.InsiderTransaction{
+				Name:      t.FilerName,
+				Relation:  t.FilerRelation,
+				URL:       t.FilerURL,
+				Text:      t.TransactionText,
+				Shares:    t.Shares,
+				Value:     t.Value,
+				Date:      t.StartDate.Time,
+				Ownership: t.Ownership,
+			})
+		}
+		return transactions
+	})}
+}
+
+// list returns an iterator over the items of a module.
+func list[T any](c Client, params *Params, m summary.Module, items func(*result) []T) *iter.Of[T] {
+	return iter.New(nil, func(*form.Values) (interface{}, []T, error) {
+		r := result{}
+		if err := c.decode(params, m, &r); err != nil {
+			return nil, nil, err
+		}
+		return nil, items(&r), nil
+	})
+}
+
+// decode requests a module of the symbol of params into r.
+func (c Client) decode(params *Params, m summary.Module, r *result) error {
+	if params == nil || len(params.Symbol) == 0 {
+		return finance.CreateArgumentError()
+	}
+	sp := &summary.Params{Params: params.Params, Symbol: params.Symbol, Modules: []summary.Module{m}}
+	return summary.Client{B: c.B}.Decode(sp, r)
+}
+
+// result is the quoteSummary result of the holders modules.
+type result struct {
+	MajorHoldersBreakdown *finance.MajorHolders `json:"majorHoldersBreakdown"`
+	InstitutionOwnership  *ownership            `json:"institutionOwnership"`
+	FundOwnership         *ownership            `json:"fundOwnership"`
+	InsiderHolders        *struct {
+		Holders []*struct {
+			Name                   string        `json:"name"`
+			Relation               string        `json:"relation"`
+			URL                    string        `json:"url"`
+			TransactionDescription string        `json:"transactionDescription"`
+			LatestTransDate        finance.Date  `json:"latestTransDate"`
+			PositionDirect         finance.Value `json:"positionDirect"`
+			PositionDirectDate     finance.Date  `json:"positionDirectDate"`
+			PositionIndirect       finance.Value `json:"positionIndirect"`
+			PositionIndirectDate   finance.Date  `json:"positionIndirectDate"`
+		} `json:"holders"`
+	} `json:"insiderHolders"`
+	InsiderTransactions *struct {
+		Transactions []*struct {
+			FilerName       string        `json:"filerName"`
+			FilerRelation   string        `json:"filerRelation"`
+			FilerURL        string        `json:"filerUrl"`
+			TransactionText string        `json:"transactionText"`
+			Shares          finance.Value `json:"shares"`
+			Value           finance.Value `json:"value"`
+			StartDate       finance.Date  `json:"startDate"`
+			Ownership       string        `json:"ownership"`
+		} `json:"transactions"`
+	} `json:"insiderTransactions"`
+}
+
+// ownership is an institutionOwnership
+// or fundOwnership module.
+type ownership struct {
+	List []*struct {
+		Organization string        `json:"organization"`
+		ReportDate   finance.Date  `json:"reportDate"`
+		PctHeld      finance.Value `json:"pctHeld"`
+		Position     finance.Value `json:"position"`
+		Value        finance.Value `json:"value"`
+		PctChange    finance.Value `json:"pctChange"`
+	} `json:"ownershipList"`
+}
+
+// holders returns the holders of o.
+func (o *ownership) holders() []*finance.Holder {
+	if o == nil {
+		return nil
+	}
+	var holders []*finance.Holder
+	for _, h := range o.List {
+		if h == nil {
+			continue
+		}
+		holders = append(holders, &finance.Holder{
+			Name:          h.Organization,
+			Shares:        h.Position,
+			Value:         h.Value,
+			PercentHeld:   h.PctHeld,
+			PercentChange: h.PctChange,
+			ReportDate:    h.ReportDate.Time,
+		})
+	}
+	return holders
+}
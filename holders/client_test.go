@@ -0,0 +1,117 @@
+package holders
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("holders modules are only served by the in-process server")
+	}
+}
+
+func TestMajor(t *testing.T) {
+	skipMock(t)
+
+	m, err := Major(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.61317, m.InstitutionsPercentHeld.Raw)
+	assert.Equal(t, "0.07%", m.InsidersPercentHeld.Fmt)
+	assert.Equal(t, int64(6142), m.InstitutionsCount.Int())
+}
+
+func TestInstitutions(t *testing.T) {
+	skipMock(t)
+
+	i := Institutions(tests.TestEquitySymbol)
+	assert.True(t, i.Next())
+	h := i.Holder()
+	assert.Equal(t, "Vanguard Group Inc", h.Name)
+	assert.Equal(t, int64(1303688506), h.Shares.Int())
+	assert.Equal(t, int64(242637528741), h.Value.Int())
+	assert.Equal(t, 0.0838, h.PercentHeld.Raw)
+	assert.Equal(t, time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC), h.ReportDate)
+	for i.Next() {
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, 3, i.Count())
+}
+
+func TestFunds(t *testing.T) {
+	skipMock(t)
+
+	i := Funds(tests.TestEquitySymbol)
+	var names []string
+	for i.Next() {
+		names = append(names, i.Holder().Name)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []string{"Vanguard Total Stock Market Index Fund", "Vanguard 500 Index Fund"}, names)
+}
+
+func TestInsiders(t *testing.T) {
+	skipMock(t)
+
+	i := Insiders(tests.TestEquitySymbol)
+	assert.True(t, i.Next())
+	assert.Equal(t, "General Counsel", i.Insider().Relation)
+	assert.Equal(t, "Sale", i.Insider().LatestTransaction)
+	assert.Equal(t, time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC), i.Insider().LatestTransactionDate)
+	assert.False(t, i.Insider().PositionIndirect.Valid)
+	assert.True(t, i.Next())
+	assert.Equal(t, int64(1000000), i.Insider().PositionIndirect.Int())
+	assert.False(t, i.Next())
+	assert.Nil(t, i.Err())
+}
+
+func TestInsiderTransactions(t *testing.T) {
+	skipMock(t)
+
+	i := InsiderTransactions(tests.TestEquitySymbol)
+	assert.True(t, i.Next())
+	tx := i.Transaction()
+	assert.Equal(t, "ADAMS KATHERINE L", tx.Name)
+	assert.Equal(t, int64(38576), tx.Shares.Int())
+	assert.Equal(t, int64(7287704), tx.Value.Int())
+	assert.Equal(t, "D", tx.Ownership)
+	assert.Equal(t, time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC), tx.Date)
+	assert.True(t, i.Next())
+	assert.False(t, i.Transaction().Value.Valid)
+}
+
+func TestHoldersContext(t *testing.T) {
+	skipMock(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := &Params{Symbol: tests.TestEquitySymbol}
+	p.Context = &ctx
+
+	i := InstitutionsP(p)
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), context.Canceled))
+}
+
+func TestBadParamsHolders(t *testing.T) {
+	_, err := MajorP(nil)
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+
+	i := Institutions("")
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
+
+func TestBadSymbolHolders(t *testing.T) {
+	skipMock(t)
+
+	i := Insiders("BADSYMBOL")
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrNotFound))
+}
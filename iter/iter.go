@@ -1,36 +1,55 @@
 package iter
 
 import (
+	"context"
+
 	"github.com/piquette/finance-go/form"
 )
 
 // Query is the function used to get a response listing.
 type Query = func(*form.Values) (interface{}, []interface{}, error)
 
-// Iter provides a convenient interface
-// for iterating over the elements
+// Pager produces the pages of a streamed listing. Each call returns
+// the meta data and items of the next page, and whether more follow.
+type Pager[T any] func(ctx context.Context) (meta interface{}, items []T, more bool, err error)
+
+// Of provides a convenient interface
+// for iterating over the elements of type T
 // returned from paginated list API calls.
 // Successive calls to the Next method
 // will step through each item in the list.
 // Iterators are not thread-safe, so they should not be consumed
 // across multiple goroutines.
-type Iter struct {
-	meta   interface{}
-	cur    interface{}
-	err    error
-	values []interface{}
+type Of[T any] struct {
+	meta     interface{}
+	cur      T
+	err      error
+	values   []T
+	ctx      context.Context
+	next     Pager[T]
+	total    int
+	consumed int
 }
 
+// Iter is an iterator over untyped elements.
+type Iter = Of[interface{}]
+
 // NewE returns a iter wrapping an error.
 func NewE(e error) *Iter {
-	iter := &Iter{}
+	return NewOfE[interface{}](e)
+}
+
+// NewOfE returns a typed iter wrapping an error.
+func NewOfE[T any](e error) *Of[T] {
+	iter := &Of[T]{}
 	iter.err = e
 	return iter
 }
 
 // New returns a new instance of Iter for a given query and its options.
-func New(qs *form.Values, query Query) *Iter {
-	iter := &Iter{}
+// The element type is that of the items returned by query.
+func New[T any](qs *form.Values, query func(*form.Values) (interface{}, []T, error)) *Of[T] {
+	iter := &Of[T]{}
 
 	q := qs
 	if q == nil {
@@ -38,18 +57,49 @@ func New(qs *form.Values, query Query) *Iter {
 	}
 
 	iter.meta, iter.values, iter.err = query(q)
+	iter.total = len(iter.values)
 	return iter
 }
 
+// Stream returns an iterator that requests the pages of next
+// as they are needed, starting with the first call to Next.
+// Once ctx is done, it yields the items already received
+// and then stops with the context error, unless the
+// stream had already ended.
+func Stream[T any](ctx context.Context, next Pager[T]) *Of[T] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Of[T]{ctx: ctx, next: next}
+}
+
 // Next advances the Iter to the next item in the list,
 // which will then be available
 // through the Current method.
 // It returns false when the iterator stops
 // at the end of the list.
-func (it *Iter) Next() bool {
+func (it *Of[T]) Next() bool {
 
-	if it.values == nil {
-		return false
+	// Items already received are yielded even once
+	// the context is done; only new pages are not requested.
+	for len(it.values) == 0 && it.next != nil {
+		if err := it.ctx.Err(); err != nil {
+			it.stop(err)
+			break
+		}
+		meta, items, more, err := it.next(it.ctx)
+		if meta != nil {
+			it.meta = meta
+		}
+		if err != nil {
+			it.stop(err)
+			break
+		}
+		it.values = items
+		it.total += len(items)
+		if !more {
+			it.next = nil
+		}
 	}
 
 	if len(it.values) == 0 {
@@ -58,18 +108,27 @@ func (it *Iter) Next() bool {
 
 	it.cur = it.values[0]
 	it.values = it.values[1:]
+	it.consumed++
 	return true
 }
 
+// stop ends a stream with an error.
+func (it *Of[T]) stop(err error) {
+	it.err = err
+	it.next = nil
+	it.values = nil
+}
+
 // Current returns the most recent item
 // visited by a call to Next.
-func (it *Iter) Current() interface{} {
+func (it *Of[T]) Current() T {
 	return it.cur
 }
 
 // Meta returns the the meta data
-// associated with the query.
-func (it *Iter) Meta() interface{} {
+// associated with the query. A streamed
+// iterator returns that of the latest page.
+func (it *Of[T]) Meta() interface{} {
 	return it.meta
 }
 
@@ -77,11 +136,44 @@ func (it *Iter) Meta() interface{} {
 // that caused the Iter to stop.
 // It must be inspected
 // after Next returns false.
-func (it *Iter) Err() error {
+func (it *Of[T]) Err() error {
 	return it.err
 }
 
-// Count returns the list count.
-func (it *Iter) Count() int {
-	return len(it.values)
+// Count returns the number of items received so far,
+// which is the list count once a stream has ended.
+func (it *Of[T]) Count() int {
+	return it.total
+}
+
+// Consumed returns the number of items
+// visited by calls to Next.
+func (it *Of[T]) Consumed() int {
+	return it.consumed
+}
+
+// Collect returns the remaining items of it,
+// along with its error.
+func Collect[T any](it *Of[T]) ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Current())
+	}
+	return items, it.Err()
+}
+
+// Filter returns an iterator over the remaining items of it
+// for which keep returns true. It advances it as it is
+// advanced, and shares its meta data and error.
+func Filter[T any](it *Of[T], keep func(T) bool) *Of[T] {
+	f := Stream(nil, func(context.Context) (interface{}, []T, bool, error) {
+		for it.Next() {
+			if v := it.Current(); keep(v) {
+				return it.Meta(), []T{v}, true, nil
+			}
+		}
+		return it.Meta(), nil, false, it.Err()
+	})
+	f.meta = it.meta
+	return f
 }
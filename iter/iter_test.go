@@ -0,0 +1,145 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func numbers(err error) *Of[int] {
+	return New(nil, func(*form.Values) (interface{}, []int, error) {
+		return "meta", []int{1, 2, 3, 4}, err
+	})
+}
+
+func TestOf(t *testing.T) {
+	it := numbers(nil)
+	assert.Equal(t, "meta", it.Meta())
+	assert.Equal(t, 4, it.Count())
+	assert.True(t, it.Next())
+	assert.Equal(t, 1, it.Current())
+	assert.Equal(t, 4, it.Count())
+	assert.Equal(t, 1, it.Consumed())
+
+	items, err := Collect(it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3, 4}, items)
+	assert.False(t, it.Next())
+}
+
+func TestOfError(t *testing.T) {
+	failure := errors.New("failure")
+	it := NewOfE[string](failure)
+	assert.False(t, it.Next())
+	assert.Equal(t, "", it.Current())
+	assert.Equal(t, failure, it.Err())
+
+	items, err := Collect(numbers(failure))
+	assert.Equal(t, []int{1, 2, 3, 4}, items)
+	assert.Equal(t, failure, err)
+
+	var legacy *Iter = NewE(failure)
+	assert.Equal(t, failure, legacy.Err())
+}
+
+func TestFilter(t *testing.T) {
+	even := Filter(numbers(nil), func(n int) bool { return n%2 == 0 })
+	assert.Equal(t, "meta", even.Meta())
+
+	items, err := Collect(even)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 4}, items)
+}
+
+// pages streams n pages of two items each.
+func pages(n int, calls *int) Pager[int] {
+	return func(ctx context.Context) (interface{}, []int, bool, error) {
+		*calls++
+		return *calls, []int{2**calls - 1, 2 * *calls}, *calls < n, nil
+	}
+}
+
+func TestStream(t *testing.T) {
+	calls := 0
+	it := Stream(context.Background(), pages(3, &calls))
+	assert.Equal(t, 0, calls)
+	assert.Nil(t, it.Meta())
+
+	assert.True(t, it.Next())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, it.Meta())
+	assert.Equal(t, 2, it.Count())
+
+	items, err := Collect(it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, items)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, it.Meta())
+	assert.Equal(t, 6, it.Count())
+	assert.Equal(t, 6, it.Consumed())
+	assert.False(t, it.Next())
+	assert.Equal(t, 3, calls)
+}
+
+func TestStreamEmptyPages(t *testing.T) {
+	calls := 0
+	it := Stream(nil, func(ctx context.Context) (interface{}, []int, bool, error) {
+		calls++
+		if calls < 3 {
+			return nil, nil, true, nil
+		}
+		return nil, []int{7}, false, nil
+	})
+	items, err := Collect(it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{7}, items)
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	it := Stream(ctx, pages(10, &calls))
+
+	assert.True(t, it.Next())
+	cancel()
+	// The rest of the page is still yielded.
+	assert.True(t, it.Next())
+	assert.Equal(t, 2, it.Current())
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), context.Canceled))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 2, it.Consumed())
+}
+
+func TestStreamCancelAfterEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	it := Stream(ctx, pages(1, &calls))
+
+	assert.True(t, it.Next())
+	cancel()
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Err())
+}
+
+func TestStreamError(t *testing.T) {
+	failure := errors.New("failure")
+	calls := 0
+	it := Stream(nil, func(ctx context.Context) (interface{}, []int, bool, error) {
+		calls++
+		if calls == 2 {
+			return nil, []int{9}, true, failure
+		}
+		return nil, []int{calls}, true, nil
+	})
+
+	items, err := Collect(it)
+	assert.Equal(t, []int{1}, items)
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, it.Count())
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package iter
+
+import (
+	goiter "iter"
+)
+
+// All returns the remaining items of it for use with
+// range-over-func loops. The error that stopped it, if any,
+// is yielded last along with the zero value of T.
+func (it *Of[T]) All() goiter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Current(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Values returns the remaining items of it for use with
+// range-over-func loops, ignoring its error.
+func (it *Of[T]) Values() goiter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.Next() {
+			if !yield(it.Current()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+//go:build go1.23
+
+package iter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	var items []int
+	for n, err := range numbers(nil).All() {
+		assert.Nil(t, err)
+		items = append(items, n)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, items)
+
+	failure := errors.New("failure")
+	var last error
+	for _, err := range numbers(failure).All() {
+		last = err
+	}
+	assert.Equal(t, failure, last)
+
+	it := numbers(nil)
+	for n := range it.Values() {
+		if n == 2 {
+			break
+		}
+	}
+	assert.True(t, it.Next())
+	assert.Equal(t, 3, it.Current())
+}
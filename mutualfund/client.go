@@ -7,6 +7,7 @@ import (
 	finance "github.com/piquette/finance-go"
 	form "github.com/piquette/finance-go/form"
 	"github.com/piquette/finance-go/iter"
+	"github.com/piquette/finance-go/summary"
 )
 
 // Client is used to invoke quote APIs.
@@ -25,19 +26,22 @@ type Params struct {
 	// quote is requested.
 	Symbols []string `form:"-"`
 	sym     string   `form:"symbols"`
+	// Batching splits long lists of symbols
+	// across several requests.
+	finance.Batching `form:"-"`
 }
 
 // Iter is an iterator for a list of quotes.
-// The embedded Iter carries methods with it;
+// The embedded iter.Of carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*iter.Iter
+	*iter.Of[*finance.MutualFund]
 }
 
 // MutualFund returns the most recent MutualFund
 // visited by a call to Next.
 func (i *Iter) MutualFund() *finance.MutualFund {
-	return i.Current().(*finance.MutualFund)
+	return i.Current()
 }
 
 // Get returns an MutualFund quote that matches the parameters specified.
@@ -65,39 +69,65 @@ func ListP(params *Params) *Iter {
 // ListP returns a quote iterator.
 func (c Client) ListP(params *Params) *Iter {
 
-	if params.Context == nil {
-		ctx := context.TODO()
-		params.Context = &ctx
-	}
-
 	// Validate input.
 	// TODO: validate symbols..
 	if params == nil || len(params.Symbols) == 0 {
-		return &Iter{iter.NewE(finance.CreateArgumentError())}
+		return &Iter{iter.NewOfE[*finance.MutualFund](finance.CreateArgumentError())}
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
 	}
-	params.sym = strings.Join(params.Symbols, ",")
 
-	body := &form.Values{}
-	form.AppendTo(body, params)
+	return &Iter{iter.New(nil, func(*form.Values) (interface{}, []*finance.MutualFund, error) {
+		results, err := finance.Batch(params.Symbols, params.Batching, func(symbols []string) ([]*finance.MutualFund, error) {
+
+			p := *params
+			p.sym = strings.Join(symbols, ",")
+			body := &form.Values{}
+			form.AppendTo(body, &p)
+
+			resp := response{}
+			err := c.B.Call("/v7/finance/quote", body, params.Context, &resp)
+			if err != nil {
+				return nil, finance.CreateRemoteError(err)
+			}
+			if resp.Inner.Error != nil {
+				return nil, resp.Inner.Error
+			}
+
+			return resp.Inner.Result, nil
+		})
+		return nil, results, err
+	})}
+}
 
-	return &Iter{iter.New(body, func(b *form.Values) (interface{}, []interface{}, error) {
+// ProfileParams carries a context and fund information.
+type ProfileParams struct {
+	finance.Params `form:"-"`
+	// Symbol is the fund profiled.
+	Symbol string `form:"-"`
+}
 
-		resp := response{}
-		err := c.B.Call("/v7/finance/quote", body, params.Context, &resp)
-		if err != nil {
-			err = finance.CreateRemoteError(err)
-		}
+// Profile returns the holdings, allocation
+// and performance of a mutual fund.
+func Profile(symbol string) (*finance.FundProfile, error) {
+	return ProfileP(&ProfileParams{Symbol: symbol})
+}
 
-		ret := make([]interface{}, len(resp.Inner.Result))
-		for i, v := range resp.Inner.Result {
-			ret[i] = v
-		}
-		if resp.Inner.Error != nil {
-			err = finance.CreateRemoteError(resp.Inner.Error)
-		}
+// ProfileP returns the profile of a mutual fund
+// and requires a params struct as an argument.
+func ProfileP(params *ProfileParams) (*finance.FundProfile, error) {
+	return getC().ProfileP(params)
+}
 
-		return nil, ret, err
-	})}
+// ProfileP returns the profile of a mutual fund.
+func (c Client) ProfileP(params *ProfileParams) (*finance.FundProfile, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+	return summary.Client{B: c.B}.GetFundProfile(&summary.Params{Params: params.Params, Symbol: params.Symbol})
 }
 
 // response is a yfin quote response.
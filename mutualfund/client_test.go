@@ -1,7 +1,9 @@
 package mutualfund
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	finance "github.com/piquette/finance-go"
 	tests "github.com/piquette/finance-go/testing"
@@ -56,3 +58,28 @@ func TestGetBadMutualFund(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+func TestProfile(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("fund profiles are only served by the in-process server")
+	}
+
+	p, err := Profile(tests.TestMutualFundSymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, tests.TestMutualFundSymbol, p.Symbol)
+	assert.Equal(t, "ProFunds", p.Family)
+	assert.Equal(t, time.Date(2001, 9, 3, 0, 0, 0, 0, time.UTC), p.InceptionDate)
+	// The expense ratio falls back to the key statistics.
+	assert.Equal(t, 0.0278, p.ExpenseRatio.Raw)
+	assert.Equal(t, 0.4, p.Turnover.Raw)
+	assert.Equal(t, 0.2585, p.Allocation.Cash.Raw)
+	assert.Equal(t, "AMZN", p.Holdings[0].Symbol)
+	assert.Empty(t, p.BondRatings)
+	assert.Equal(t, -0.0876, p.Performance.ThreeYear.Raw)
+	assert.Len(t, p.Performance.Annual, 2)
+}
+
+func TestBadProfile(t *testing.T) {
+	_, err := Profile("")
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+}
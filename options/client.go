@@ -35,17 +35,18 @@ type Params struct {
 // and related metadata for a
 // yfin option straddles request.
 type StraddleIter struct {
-	*iter.Iter
+	*iter.Of[*finance.Straddle]
 }
 
 // Straddle returns the current straddle in the iter.
 func (si *StraddleIter) Straddle() *finance.Straddle {
-	return si.Current().(*finance.Straddle)
+	return si.Current()
 }
 
 // Meta returns the metadata associated with the options response.
 func (si *StraddleIter) Meta() *finance.OptionsMeta {
-	return si.Iter.Meta().(*finance.OptionsMeta)
+	meta, _ := si.Of.Meta().(*finance.OptionsMeta)
+	return meta
 }
 
 // GetStraddle returns options straddles.
@@ -67,7 +68,7 @@ func (c Client) GetStraddleP(params *Params) *StraddleIter {
 	// Construct request from params input.
 	// TODO: validate symbol..
 	if params == nil || len(params.UnderlyingSymbol) == 0 {
-		return &StraddleIter{iter.NewE(finance.CreateArgumentError())}
+		return &StraddleIter{iter.NewOfE[*finance.Straddle](finance.CreateArgumentError())}
 	}
 
 	if params.Context == nil {
@@ -84,11 +85,12 @@ func (c Client) GetStraddleP(params *Params) *StraddleIter {
 	body := &form.Values{}
 	form.AppendTo(body, params)
 
-	return &StraddleIter{iter.New(body, func(b *form.Values) (meta interface{}, values []interface{}, err error) {
+	return &StraddleIter{iter.New(body, func(b *form.Values) (meta interface{}, values []*finance.Straddle, err error) {
 
 		resp := response{}
 		err = c.B.Call("/v7/finance/options/"+params.UnderlyingSymbol, body, params.Context, &resp)
 		if err != nil {
+			err = finance.CreateRemoteError(err)
 			return
 		}
 
@@ -97,15 +99,19 @@ func (c Client) GetStraddleP(params *Params) *StraddleIter {
 			return
 		}
 
-		result := resp.Inner.Results[0]
-		if result == nil {
-			err = finance.CreateRemoteErrorS("no results in option straddle response")
+		if len(resp.Inner.Results) == 0 || resp.Inner.Results[0] == nil {
+			err = finance.CreateNotFoundError(params.UnderlyingSymbol)
 			return
 		}
+		result := resp.Inner.Results[0]
 
 		var list []straddleOptions
 		err = json.Unmarshal(result.Options, &list)
-		if err != nil || len(list) < 1 {
+		if err != nil {
+			err = finance.CreateDecodeError(err, result.Options)
+			return
+		}
+		if len(list) < 1 {
 			err = finance.CreateRemoteErrorS("no results in option straddle response")
 			return
 		}
@@ -119,10 +125,7 @@ func (c Client) GetStraddleP(params *Params) *StraddleIter {
 			HasMiniOptions:     ls.HasMiniOptions,
 			Quote:              result.Quote,
 		}
-		for _, straddle := range ls.Straddles {
-			values = append(values, straddle)
-		}
-
+		values = ls.Straddles
 		return
 	})}
 }
@@ -2,8 +2,10 @@ package quote
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"strings"
+	"time"
 
 	finance "github.com/piquette/finance-go"
 	chart "github.com/piquette/finance-go/chart"
@@ -28,27 +30,30 @@ type Params struct {
 	// quote is requested.
 	Symbols []string `form:"-"`
 	sym     string   `form:"symbols"`
+	// Batching splits long lists of symbols
+	// across several requests.
+	finance.Batching `form:"-"`
 }
 
 // Iter is an iterator for a list of quotes.
-// The embedded Iter carries methods with it;
+// The embedded iter.Of carries methods with it;
 // see its documentation for details.
 type Iter struct {
-	*iter.Iter
+	*iter.Of[*finance.Quote]
 }
 
 // Quote returns the most recent Quote
 // visited by a call to Next.
 func (i *Iter) Quote() *finance.Quote {
-	return i.Current().(*finance.Quote)
+	return i.Current()
 }
 
 // GetHistoricalQuote provides a single chart bar for a historical date.
 func GetHistoricalQuote(symbol string, month int, day int, year int) (*finance.ChartBar, error) {
 	p := &chart.Params{
 		Symbol:   symbol,
-		Start:    &datetime.Datetime{Month: month, Day: day, Year: year},
-		End:      &datetime.Datetime{Month: month, Day: day, Year: year},
+		Start:    datetime.Date(year, time.Month(month), day, nil),
+		End:      datetime.Date(year, time.Month(month), day, nil),
 		Interval: datetime.OneDay,
 	}
 	iter := chart.Get(p)
@@ -63,8 +68,12 @@ func GetHistoricalQuote(symbol string, month int, day int, year int) (*finance.C
 func Get(symbol string) (*finance.Quote, error) {
 	i := List([]string{symbol})
 
+	if i.Err() != nil {
+		return nil, i.Err()
+	}
+
 	if i.Count() == 0 {
-		return nil, fmt.Errorf("Can't find quote for symbol: %s", symbol)
+		return nil, finance.CreateNotFoundError(symbol)
 	}
 
 	if !i.Next() {
@@ -88,45 +97,135 @@ func ListP(params *Params) *Iter {
 // ListP returns a quote iterator.
 func (c Client) ListP(params *Params) *Iter {
 
-	if params.Context == nil {
-		ctx := context.TODO()
-		params.Context = &ctx
-	}
-
 	// Validate input.
 	// TODO: validate symbols..
 	if params == nil || len(params.Symbols) == 0 {
-		return &Iter{iter.NewE(finance.CreateArgumentError())}
+		return &Iter{iter.NewOfE[*finance.Quote](finance.CreateArgumentError())}
 	}
-	params.sym = strings.Join(params.Symbols, ",")
 
-	body := &form.Values{}
-	form.AppendTo(body, params)
+	return &Iter{iter.New(nil, func(*form.Values) (interface{}, []*finance.Quote, error) {
+		results, err := list[*finance.Quote](c, params)
+		return nil, results, err
+	})}
+}
 
-	return &Iter{iter.New(body, func(b *form.Values) (interface{}, []interface{}, error) {
+// TypedIter is an iterator for a list of quotes
+// decoded into the type matching their quoteType.
+type TypedIter struct {
+	*iter.Of[finance.Quoter]
+}
 
-		resp := response{}
-		err := c.B.Call("/v7/finance/quote", body, params.Context, &resp)
-		if err != nil {
-			err = finance.CreateRemoteError(err)
+// Quoter returns the most recent quote
+// visited by a call to Next.
+func (i *TypedIter) Quoter() finance.Quoter {
+	return i.Current()
+}
+
+// GetTyped returns the quote of a symbol
+// decoded into the type matching its quoteType.
+func GetTyped(symbol string) (finance.Quoter, error) {
+	i := ListTyped([]string{symbol})
+
+	if !i.Next() {
+		if i.Err() != nil {
+			return nil, i.Err()
+		}
+		return nil, finance.CreateNotFoundError(symbol)
+	}
+
+	return i.Quoter(), nil
+}
+
+// ListTyped returns several quotes, each decoded into
+// the type matching its quoteType.
+func ListTyped(symbols []string) *TypedIter {
+	return ListTypedP(&Params{Symbols: symbols})
+}
+
+// ListTypedP returns a typed quote iterator and requires
+// a params struct as an argument.
+func ListTypedP(params *Params) *TypedIter {
+	return getC().ListTypedP(params)
+}
+
+// ListTypedP returns a typed quote iterator. Quotes that cannot be
+// decoded are skipped and reported, along with any failed batch,
+// through a BatchError once the other quotes are visited.
+func (c Client) ListTypedP(params *Params) *TypedIter {
+
+	if params == nil || len(params.Symbols) == 0 {
+		return &TypedIter{iter.NewOfE[finance.Quoter](finance.CreateArgumentError())}
+	}
+
+	return &TypedIter{iter.New(nil, func(*form.Values) (interface{}, []finance.Quoter, error) {
+		raw, err := list[json.RawMessage](c, params)
+		results := make([]finance.Quoter, 0, len(raw))
+		var failures []*finance.BatchFailure
+		for _, r := range raw {
+			q, derr := finance.DecodeQuote(r)
+			if derr != nil {
+				failures = append(failures, &finance.BatchFailure{Symbols: symbolOf(r), Err: derr})
+				continue
+			}
+			results = append(results, q)
+		}
+		if len(failures) == 0 {
+			return nil, results, err
 		}
+		var berr *finance.BatchError
+		if errors.As(err, &berr) {
+			berr.Failures = append(berr.Failures, failures...)
+			return nil, results, berr
+		}
+		return nil, results, &finance.BatchError{Failures: failures}
+	})}
+}
 
-		ret := make([]interface{}, len(resp.Inner.Result))
-		for i, v := range resp.Inner.Result {
-			ret[i] = v
+// symbolOf returns the symbol of a raw quote,
+// or nil if it cannot be read.
+func symbolOf(raw json.RawMessage) []string {
+	var q struct {
+		Symbol string `json:"symbol"`
+	}
+	if json.Unmarshal(raw, &q) != nil || q.Symbol == "" {
+		return nil
+	}
+	return []string{q.Symbol}
+}
+
+// list requests the quotes of params in batches,
+// decoding each result into a T.
+func list[T any](c Client, params *Params) ([]T, error) {
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	return finance.Batch(params.Symbols, params.Batching, func(symbols []string) ([]T, error) {
+
+		p := *params
+		p.sym = strings.Join(symbols, ",")
+		body := &form.Values{}
+		form.AppendTo(body, &p)
+
+		resp := response[T]{}
+		err := c.B.Call("/v7/finance/quote", body, params.Context, &resp)
+		if err != nil {
+			return nil, finance.CreateRemoteError(err)
 		}
 		if resp.Inner.Error != nil {
-			err = finance.CreateRemoteError(resp.Inner.Error)
+			return nil, resp.Inner.Error
 		}
 
-		return nil, ret, err
-	})}
+		return resp.Inner.Result, nil
+	})
 }
 
 // response is a yfin quote response.
-type response struct {
+type response[T any] struct {
 	Inner struct {
-		Result []*finance.Quote   `json:"result"`
+		Result []T                `json:"result"`
 		Error  *finance.YfinError `json:"error"`
 	} `json:"quoteResponse"`
 }
@@ -1,9 +1,13 @@
 package quote
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
 	tests "github.com/piquette/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -72,6 +76,7 @@ func TestNilParamsQuote(t *testing.T) {
 
 	assert.False(t, iter.Next())
 	assert.Equal(t, "code: api-error, detail: missing function argument", iter.Err().Error())
+	assert.True(t, errors.Is(iter.Err(), finance.ErrArgument))
 }
 
 func TestGetBadQuote(t *testing.T) {
@@ -80,4 +85,128 @@ func TestGetBadQuote(t *testing.T) {
 	q, err := Get("TEST")
 	assert.Nil(t, q)
 	assert.Equal(t, "Can't find quote for symbol: TEST", err.Error())
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+func TestListBatches(t *testing.T) {
+	symbols := []string{
+		tests.TestEquitySymbol,
+		tests.TestETFSymbol,
+		tests.TestFutureSymbol,
+		tests.TestIndexSymbol,
+		tests.TestMutualFundSymbol,
+		tests.TestCryptoPairSymbol,
+	}
+	p := &Params{Symbols: symbols}
+	p.BatchSize = 2
+	p.Concurrency = 3
+
+	var got []string
+	i := ListP(p)
+	for i.Next() {
+		got = append(got, i.Quote().Symbol)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, symbols, got)
+}
+
+func TestListBatchFailure(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("faults are only injected by the in-process server")
+	}
+	tests.DefaultServer.SetFault(tests.Fault{StatusCode: 503, Count: 1})
+	defer tests.DefaultServer.ClearFault()
+
+	symbols := []string{tests.TestEquitySymbol, tests.TestETFSymbol, tests.TestIndexSymbol}
+	p := &Params{Symbols: symbols}
+	p.BatchSize = 2
+
+	i := ListP(p)
+	assert.True(t, i.Next())
+	assert.Equal(t, tests.TestIndexSymbol, i.Quote().Symbol)
+	assert.False(t, i.Next())
+
+	var berr *finance.BatchError
+	assert.True(t, errors.As(i.Err(), &berr))
+	assert.Equal(t, symbols[:2], berr.Failures[0].Symbols)
+	assert.True(t, errors.Is(i.Err(), finance.ErrRemote))
+}
+
+func TestListTyped(t *testing.T) {
+	symbols := []string{
+		tests.TestEquitySymbol,
+		tests.TestETFSymbol,
+		tests.TestFutureSymbol,
+		tests.TestIndexSymbol,
+		tests.TestOptionSymbol,
+		tests.TestMutualFundSymbol,
+		tests.TestForexPairSymbol,
+		tests.TestCryptoPairSymbol,
+	}
+
+	var types []string
+	i := ListTyped(symbols)
+	for i.Next() {
+		switch q := i.Quoter().(type) {
+		case *finance.Equity:
+			types = append(types, "equity")
+		case *finance.ETF:
+			types = append(types, "etf")
+		case *finance.Future:
+			types = append(types, "future")
+		case *finance.Index:
+			types = append(types, "index")
+		case *finance.Option:
+			types = append(types, "option")
+			assert.NotZero(t, q.Strike)
+		case *finance.MutualFund:
+			types = append(types, "mutualfund")
+		case *finance.ForexPair:
+			types = append(types, "forex")
+		case *finance.CryptoPair:
+			types = append(types, "crypto")
+		}
+		assert.Equal(t, symbols[len(types)-1], i.Quoter().GetQuote().Symbol)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []string{"equity", "etf", "future", "index", "option", "mutualfund", "forex", "crypto"}, types)
+}
+
+func TestGetTyped(t *testing.T) {
+	q, err := GetTyped(tests.TestCryptoPairSymbol)
+	assert.Nil(t, err)
+	assert.IsType(t, &finance.CryptoPair{}, q)
+
+	_, err = GetTyped("BADSYMBOL")
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+// rawBackend answers every call with body.
+type rawBackend struct {
+	body string
+}
+
+func (b rawBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	return json.Unmarshal([]byte(b.body), v)
+}
+
+func TestListTypedDecodeFailure(t *testing.T) {
+	c := Client{B: rawBackend{`{"quoteResponse": {"result": [
+		{"symbol": "AAPL", "quoteType": "EQUITY", "regularMarketPrice": 174.29},
+		{"symbol": "BAD", "quoteType": "EQUITY", "regularMarketPrice": "n/a"},
+		{"symbol": "SPY", "quoteType": "ETF", "regularMarketPrice": 273.97}
+	], "error": null}}`}}
+
+	var got []string
+	i := c.ListTypedP(&Params{Symbols: []string{"AAPL", "BAD", "SPY"}})
+	for i.Next() {
+		got = append(got, i.Quoter().GetQuote().Symbol)
+	}
+	assert.Equal(t, []string{"AAPL", "SPY"}, got)
+
+	var berr *finance.BatchError
+	assert.True(t, errors.As(i.Err(), &berr))
+	assert.Len(t, berr.Failures, 1)
+	assert.Equal(t, []string{"BAD"}, berr.Failures[0].Symbols)
+	assert.True(t, errors.Is(i.Err(), finance.ErrDecode))
 }
@@ -0,0 +1,62 @@
+package finance
+
+import (
+	"encoding/json"
+)
+
+// Quoter is implemented by every quote type through its
+// embedded Quote. The concrete type of a Quoter returned by
+// DecodeQuote depends on its QuoteType, so it can be inspected
+// with a type switch:
+//
+//	switch q := q.(type) {
+//	case *finance.Equity:
+//		fmt.Println(q.EpsTrailingTwelveMonths)
+//	case *finance.CryptoPair:
+//		fmt.Println(q.CirculatingSupply)
+//	}
+type Quoter interface {
+	GetQuote() *Quote
+}
+
+// GetQuote returns q.
+func (q *Quote) GetQuote() *Quote {
+	return q
+}
+
+// DecodeQuote decodes a single quote into the type matching
+// its quoteType, or into a *Quote if that type is not known.
+func DecodeQuote(data []byte) (Quoter, error) {
+	var kind struct {
+		QuoteType QuoteType `json:"quoteType"`
+	}
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return nil, CreateDecodeError(err, data)
+	}
+
+	var q Quoter
+	switch kind.QuoteType {
+	case QuoteTypeEquity:
+		q = &Equity{}
+	case QuoteTypeETF:
+		q = &ETF{}
+	case QuoteTypeMutualFund:
+		q = &MutualFund{}
+	case QuoteTypeIndex:
+		q = &Index{}
+	case QuoteTypeOption:
+		q = &Option{}
+	case QuoteTypeFuture:
+		q = &Future{}
+	case QuoteTypeForexPair:
+		q = &ForexPair{}
+	case QuoteTypeCryptoPair:
+		q = &CryptoPair{}
+	default:
+		q = &Quote{}
+	}
+	if err := json.Unmarshal(data, q); err != nil {
+		return nil, CreateDecodeError(err, data)
+	}
+	return q, nil
+}
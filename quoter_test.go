@@ -0,0 +1,32 @@
+package finance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeQuote(t *testing.T) {
+	q, err := DecodeQuote([]byte(`{"quoteType":"EQUITY","symbol":"AAPL","trailingPE":18.5}`))
+	assert.Nil(t, err)
+	equity, ok := q.(*Equity)
+	assert.True(t, ok)
+	assert.Equal(t, "AAPL", equity.Symbol)
+	assert.Equal(t, 18.5, equity.TrailingPE)
+	assert.Equal(t, &equity.Quote, q.GetQuote())
+
+	q, err = DecodeQuote([]byte(`{"quoteType":"OPTION","symbol":"AMD180720C00003000","strike":3}`))
+	assert.Nil(t, err)
+	option, ok := q.(*Option)
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, option.Strike)
+
+	q, err = DecodeQuote([]byte(`{"quoteType":"WARRANT","symbol":"XYZ-WT"}`))
+	assert.Nil(t, err)
+	assert.IsType(t, &Quote{}, q)
+	assert.Equal(t, "XYZ-WT", q.GetQuote().Symbol)
+
+	_, err = DecodeQuote([]byte(`{"quoteType":"EQUITY","trailingPE":"n/a"}`))
+	assert.True(t, errors.Is(err, ErrDecode))
+}
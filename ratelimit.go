@@ -0,0 +1,186 @@
+package finance
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a client-side token bucket limiter attached to a backend.
+// Every request made through the backend takes a token from the global
+// bucket, and from the bucket of the request's host when a per-host
+// limit is set. Requests block until tokens are available or their
+// context is done. A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	global *bucket
+	hosts  map[string]*bucket
+	stats  RateLimiterStats
+}
+
+// RateLimiterStats are cumulative wait-time statistics of a RateLimiter.
+type RateLimiterStats struct {
+	// Requests is the number of requests that were granted a token.
+	Requests int64
+	// Delayed is the number of requests that had to wait for a token.
+	Delayed int64
+	// Rejected is the number of requests abandoned because their
+	// context expired, or would have, before a token was available.
+	Rejected int64
+	// TotalWait is the time spent waiting across all requests.
+	TotalWait time.Duration
+	// MaxWait is the longest single wait.
+	MaxWait time.Duration
+}
+
+// AverageWait returns the mean wait per granted request.
+func (s RateLimiterStats) AverageWait() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalWait / time.Duration(s.Requests)
+}
+
+// NewRateLimiter returns a limiter allowing rps requests per second
+// on average, with bursts of up to burst requests.
+// A non-positive rps disables the global limit.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		global: newBucket(rps, burst),
+		hosts:  make(map[string]*bucket),
+	}
+}
+
+// SetHostLimit sets a limit of rps requests per second, with bursts of
+// up to burst requests, for requests sent to host. The host is matched
+// against the request URL host, including any port.
+// A non-positive rps removes the host limit.
+func (l *RateLimiter) SetHostLimit(host string, rps float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rps <= 0 {
+		delete(l.hosts, host)
+		return
+	}
+	l.hosts[host] = newBucket(rps, burst)
+}
+
+// Stats returns a snapshot of the limiter's wait-time statistics.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// Wait blocks until a request to host may proceed or ctx is done.
+// It returns the context's error if ctx is done first, and
+// context.DeadlineExceeded without waiting if ctx would expire
+// before a token becomes available.
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	hb := l.hosts[host]
+	delay := l.global.reserve(now)
+	if hb != nil {
+		if d := hb.reserve(now); d > delay {
+			delay = d
+		}
+	}
+	if delay == 0 {
+		l.stats.Requests++
+		l.mu.Unlock()
+		return nil
+	}
+	l.mu.Unlock()
+
+	err := ctx.Err()
+	if err == nil {
+		if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+			err = context.DeadlineExceeded
+		}
+	}
+	if err == nil {
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-t.C:
+		}
+		t.Stop()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		// Hand the reserved tokens back so that
+		// later requests are not penalized.
+		l.global.cancel()
+		if hb != nil {
+			hb.cancel()
+		}
+		l.stats.Rejected++
+		return err
+	}
+	l.stats.Requests++
+	l.stats.Delayed++
+	l.stats.TotalWait += delay
+	if delay > l.stats.MaxWait {
+		l.stats.MaxWait = delay
+	}
+	return nil
+}
+
+// bucket is a single token bucket. Tokens may go negative,
+// which represents requests already queued for future tokens.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rps float64, burst int) *bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// reserve takes a token and returns how long
+// the caller must wait before using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve.
+func (b *bucket) cancel() {
+	if b.rate <= 0 {
+		return
+	}
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
@@ -0,0 +1,127 @@
+package finance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, l.Wait(ctx, "example.com"))
+	}
+
+	assert.Equal(t, int64(3), l.Stats().Requests)
+	assert.Equal(t, int64(0), l.Stats().Delayed)
+}
+
+func TestRateLimiterThrottles(t *testing.T) {
+	l := NewRateLimiter(20, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, l.Wait(ctx, "example.com"))
+	}
+
+	// One token up front, then four at 50ms each.
+	assert.True(t, time.Since(start) >= 150*time.Millisecond)
+	stats := l.Stats()
+	assert.Equal(t, int64(5), stats.Requests)
+	assert.Equal(t, int64(4), stats.Delayed)
+	assert.True(t, stats.MaxWait > 0)
+	assert.True(t, stats.TotalWait >= stats.MaxWait)
+	assert.True(t, stats.AverageWait() > 0)
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := NewRateLimiter(50, 5)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, l.Wait(ctx, "example.com"))
+		}()
+	}
+	wg.Wait()
+
+	// Five tokens up front, then five at 20ms each.
+	assert.True(t, time.Since(start) >= 80*time.Millisecond)
+	assert.Equal(t, int64(10), l.Stats().Requests)
+}
+
+func TestRateLimiterHostLimit(t *testing.T) {
+	l := NewRateLimiter(0, 0)
+	l.SetHostLimit("slow.example.com", 1, 1)
+	ctx := context.Background()
+
+	assert.Nil(t, l.Wait(ctx, "slow.example.com"))
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, l.Wait(ctx, "fast.example.com"))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx, "slow.example.com"))
+
+	l.SetHostLimit("slow.example.com", 0, 0)
+	assert.Nil(t, l.Wait(ctx, "slow.example.com"))
+}
+
+func TestRateLimiterContext(t *testing.T) {
+	l := NewRateLimiter(1.0/60, 1)
+	assert.Nil(t, l.Wait(context.Background(), "example.com"))
+
+	// The next token is a minute away, past the deadline,
+	// so the wait is rejected before the deadline passes.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx, "example.com"))
+	assert.Nil(t, ctx.Err())
+
+	// Cancellation interrupts a wait in progress.
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	assert.Equal(t, context.Canceled, l.Wait(ctx, "example.com"))
+
+	assert.Equal(t, int64(2), l.Stats().Rejected)
+}
+
+func TestRateLimiterNil(t *testing.T) {
+	var l *RateLimiter
+	assert.Nil(t, l.Wait(context.Background(), "example.com"))
+}
+
+func TestBackendRateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	l := NewRateLimiter(0, 0)
+	l.SetHostLimit(u.Host, 20, 1)
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Limiter: l}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, b.Call("/", nil, nil, nil))
+	}
+
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+	assert.Equal(t, int64(3), l.Stats().Requests)
+	assert.Equal(t, int64(2), l.Stats().Delayed)
+}
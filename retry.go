@@ -0,0 +1,179 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+	defaultRetryJitter      = 0.5
+)
+
+// RetryPolicy describes how a backend retries requests
+// that failed for transient reasons.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Each following
+	// retry doubles it.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay. A response asking,
+	// through Retry-After, to wait longer than MaxDelay is not retried.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) of each delay that is randomized
+	// so that concurrent clients do not retry in lockstep.
+	Jitter float64
+	// RetryableStatusCodes lists the response status codes
+	// that are worth retrying.
+	RetryableStatusCodes []int
+	// RetryableError reports whether a transport error is worth retrying.
+	// If nil, timeouts and dropped or refused connections are retried.
+	RetryableError func(error) bool
+}
+
+// NewRetryPolicy returns a retry policy with sensible defaults:
+// three attempts with exponential backoff starting at 500ms, retrying
+// 429, 500, 502, 503 and 504 responses as well as timeouts and
+// dropped or refused connections.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		Jitter:      defaultRetryJitter,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// attempts returns the total number of attempts allowed by the policy.
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// retryableStatus reports whether a response status code should be retried.
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// retryableError reports whether a transport error should be retried.
+func (p *RetryPolicy) retryableError(err error) bool {
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return isTransientNetError(err)
+}
+
+// backoff returns the delay to wait before the given retry,
+// where retry 1 is the first retry after the initial attempt.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
+
+// isTransientNetError reports whether err looks like a timeout or a
+// dropped or refused connection. Other transport errors, such as an
+// unsupported scheme, a bad certificate or an unknown host, are permanent.
+func isTransientNetError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// waitTooLong reports whether the server asked
+// to wait longer than the policy allows.
+func (p *RetryPolicy) waitTooLong(wait time.Duration) bool {
+	return p.MaxDelay > 0 && wait > p.MaxDelay
+}
+
+// parseRetryAfter parses the value of a Retry-After header,
+// which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleepCtx waits for d or until ctx is done, whichever comes first.
+// It returns false without waiting if ctx would expire before d elapses.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
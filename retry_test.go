@@ -0,0 +1,246 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyServer answers the first `failures` requests with `status`
+// and every following request with a small JSON body.
+func flakyServer(failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	return srv, &calls
+}
+
+func testPolicy() *RetryPolicy {
+	p := NewRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 5 * time.Millisecond
+	return p
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		srv, calls := flakyServer(2, status, nil)
+		b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: testPolicy()}
+
+		var v struct{ OK bool }
+		err := b.Call("/", nil, nil, &v)
+
+		assert.Nil(t, err)
+		assert.True(t, v.OK)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+		srv.Close()
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv, calls := flakyServer(10, http.StatusServiceUnavailable, nil)
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: testPolicy()}
+
+	err := b.Call("/", nil, nil, nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestRetryNotRetryableStatus(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusNotFound, nil)
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: testPolicy()}
+
+	err := b.Call("/", nil, nil, nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryDisabled(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusServiceUnavailable, nil)
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client()}
+
+	err := b.Call("/", nil, nil, nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	defer srv.Close()
+	p := testPolicy()
+	p.MaxDelay = 2 * time.Second
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: p}
+
+	start := time.Now()
+	err := b.Call("/", nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	assert.True(t, time.Since(start) >= time.Second)
+}
+
+func TestRetryAfterBeyondMaxDelay(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}})
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: testPolicy()}
+
+	start := time.Now()
+	err := b.Call("/", nil, nil, nil)
+
+	// The request is not retried rather than block for an hour.
+	var herr *HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusTooManyRequests, herr.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	srv, calls := flakyServer(10, http.StatusServiceUnavailable, http.Header{"Retry-After": {"30"}})
+	defer srv.Close()
+	p := testPolicy()
+	p.MaxDelay = time.Minute
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: p}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := b.Call("/", nil, &ctx, nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	srv, _ := flakyServer(0, http.StatusOK, nil)
+	url := srv.URL
+	srv.Close()
+
+	var attempts int32
+	p := testPolicy()
+	p.RetryableError = func(err error) bool {
+		atomic.AddInt32(&attempts, 1)
+		return true
+	}
+	b := &BackendConfiguration{Type: YFinBackend, URL: url, HTTPClient: &http.Client{}, Retry: p}
+
+	err := b.Call("/", nil, nil, nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+// countingTransport counts the requests it sends.
+type countingTransport struct {
+	calls int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.calls, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRetryPermanentNetworkError(t *testing.T) {
+	tr := &countingTransport{}
+	b := &BackendConfiguration{Type: YFinBackend, URL: "ftp://127.0.0.1", HTTPClient: &http.Client{Transport: tr}, Retry: testPolicy()}
+
+	err := b.Call("/", nil, nil, nil)
+
+	assert.True(t, errors.Is(err, ErrRemote))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tr.calls))
+}
+
+func TestTransientNetError(t *testing.T) {
+	assert.True(t, isTransientNetError(&url.Error{Op: "Get", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}))
+	assert.True(t, isTransientNetError(&url.Error{Op: "Get", Err: io.ErrUnexpectedEOF}))
+	assert.True(t, isTransientNetError(&net.DNSError{Err: "timeout", IsTimeout: true}))
+	assert.False(t, isTransientNetError(&url.Error{Op: "Get", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}))
+	assert.False(t, isTransientNetError(&url.Error{Op: "Get", Err: errors.New("unsupported protocol scheme")}))
+	assert.False(t, isTransientNetError(&url.Error{Op: "Get", Err: context.Canceled}))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(3))
+	assert.Equal(t, time.Second, p.backoff(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.backoff(2)
+		assert.True(t, d > 100*time.Millisecond && d <= 200*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2018, 1, 11, 12, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, d)
+
+	d, ok = parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+}
+
+func TestRetryPostBody(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"method":"` + r.Method + `","type":"` + r.Header.Get("Content-Type") + `","body":` + string(data) + `}`))
+	}))
+	defer srv.Close()
+	b := &BackendConfiguration{Type: YFinBackend, URL: srv.URL, HTTPClient: srv.Client(), Retry: testPolicy()}
+
+	var v struct {
+		Method string
+		Type   string
+		Body   struct{ Size int }
+	}
+	assert.Nil(t, Post(b, "/", nil, map[string]int{"size": 25}, nil, &v))
+	assert.Equal(t, "POST", v.Method)
+	assert.Equal(t, "application/json", v.Type)
+	// The body is sent again with the retried request.
+	assert.Equal(t, 25, v.Body.Size)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,274 @@
+// Package screener discovers symbols by criteria, with the
+// predefined screens of yahoo finance or custom queries.
+package screener
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/iter"
+)
+
+const (
+	// DefaultPageSize is the number of quotes requested
+	// at once when Params.PageSize is zero.
+	DefaultPageSize = 25
+	// MaxPageSize is the largest page yahoo serves.
+	MaxPageSize = 250
+)
+
+// Screen is the id of a predefined screen.
+type Screen string
+
+const (
+	// AggressiveSmallCaps are small caps with earnings growth above 25%.
+	AggressiveSmallCaps Screen = "aggressive_small_caps"
+	// ConservativeForeignFunds are low risk foreign funds.
+	ConservativeForeignFunds Screen = "conservative_foreign_funds"
+	// DayGainers are the equities up the most today.
+	DayGainers Screen = "day_gainers"
+	// DayLosers are the equities down the most today.
+	DayLosers Screen = "day_losers"
+	// GrowthTechnologyStocks are tech stocks with revenue and earnings growth above 25%.
+	GrowthTechnologyStocks Screen = "growth_technology_stocks"
+	// HighYieldBond are high yield bond funds.
+	HighYieldBond Screen = "high_yield_bond"
+	// MostActives are the equities traded the most today.
+	MostActives Screen = "most_actives"
+	// MostShortedStocks are the equities with the highest short interest.
+	MostShortedStocks Screen = "most_shorted_stocks"
+	// PortfolioAnchors are funds suited to anchor a portfolio.
+	PortfolioAnchors Screen = "portfolio_anchors"
+	// SmallCapGainers are small caps up the most today.
+	SmallCapGainers Screen = "small_cap_gainers"
+	// SolidLargeGrowthFunds are highly rated large growth funds.
+	SolidLargeGrowthFunds Screen = "solid_large_growth_funds"
+	// SolidMidcapGrowthFunds are highly rated mid-cap growth funds.
+	SolidMidcapGrowthFunds Screen = "solid_midcap_growth_funds"
+	// TopMutualFunds are the best performing mutual funds.
+	TopMutualFunds Screen = "top_mutual_funds"
+	// UndervaluedGrowthStocks have earnings growth above 25% and low P/E and PEG ratios.
+	UndervaluedGrowthStocks Screen = "undervalued_growth_stocks"
+	// UndervaluedLargeCaps are large caps with low P/E and PEG ratios.
+	UndervaluedLargeCaps Screen = "undervalued_large_caps"
+)
+
+// Client is used to invoke screener APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and screen information.
+// Exactly one of Screen and Query must be set.
+type Params struct {
+	finance.Params `form:"-"`
+	// Screen is a predefined screen.
+	Screen Screen `form:"-"`
+	// Query is a custom screen.
+	Query *Query `form:"-"`
+	// QuoteType is the quote type screened by Query,
+	// equities if empty.
+	QuoteType finance.QuoteType `form:"-"`
+	// SortField orders the quotes of Query, Ticker if empty.
+	SortField Field `form:"-"`
+	// Ascending sorts the quotes of Query in ascending order.
+	Ascending bool `form:"-"`
+	// PageSize is the number of quotes requested at once.
+	PageSize int `form:"-"`
+	// Limit, if positive, is the largest number of quotes returned.
+	Limit int `form:"-"`
+
+	scrIds string `form:"scrIds"`
+	count  int    `form:"count"`
+	start  int    `form:"start"`
+}
+
+// Iter is an iterator for the quotes passing a screen.
+// The embedded iter.Of carries methods with it;
+// see its documentation for details.
+type Iter struct {
+	*iter.Of[*finance.Quote]
+}
+
+// Quote returns the most recent quote
+// visited by a call to Next.
+func (i *Iter) Quote() *finance.Quote {
+	return i.Current()
+}
+
+// Meta returns the meta data associated with the screen,
+// once the first page is received.
+func (i *Iter) Meta() *finance.ScreenMeta {
+	meta, _ := i.Of.Meta().(*finance.ScreenMeta)
+	return meta
+}
+
+// Predefined returns the quotes passing a predefined screen.
+func Predefined(screen Screen) *Iter {
+	return ListP(&Params{Screen: screen})
+}
+
+// Custom returns the equities matching query.
+func Custom(query *Query) *Iter {
+	return ListP(&Params{Query: query})
+}
+
+// ListP returns the quotes passing a screen
+// and requires a params struct as an argument.
+func ListP(params *Params) *Iter {
+	return getC().ListP(params)
+}
+
+// ListP returns the quotes passing a screen. Pages are
+// requested as they are needed, starting with the first call to Next.
+func (c Client) ListP(params *Params) *Iter {
+
+	if params == nil || (len(params.Screen) == 0) == (params.Query == nil) ||
+		params.PageSize < 0 || params.PageSize > MaxPageSize || params.Limit < 0 {
+		return &Iter{iter.NewOfE[*finance.Quote](finance.CreateArgumentError())}
+	}
+	if params.Query != nil && !params.Query.valid() {
+		return &Iter{iter.NewOfE[*finance.Quote](finance.CreateArgumentErrorS("invalid screener query"))}
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	size := params.PageSize
+	if size == 0 {
+		size = DefaultPageSize
+	}
+
+	offset := 0
+	return &Iter{iter.Stream(*params.Context, func(context.Context) (interface{}, []*finance.Quote, bool, error) {
+
+		n := size
+		if params.Limit > 0 && params.Limit-offset < n {
+			n = params.Limit - offset
+		}
+
+		var (
+			r   *result
+			err error
+		)
+		if params.Query != nil {
+			r, err = c.custom(params, offset, n)
+		} else {
+			r, err = c.predefined(params, offset, n)
+		}
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		quotes := make([]*finance.Quote, 0, len(r.Quotes))
+		for _, q := range r.Quotes {
+			if q != nil {
+				quotes = append(quotes, q)
+			}
+		}
+		if len(quotes) > n {
+			quotes = quotes[:n]
+		}
+		offset += len(r.Quotes)
+
+		meta := &finance.ScreenMeta{ID: r.ID, Title: r.Title, Description: r.Description, Total: r.Total}
+		more := len(r.Quotes) > 0 && offset < r.Total && (params.Limit == 0 || offset < params.Limit)
+		return meta, quotes, more, nil
+	})}
+}
+
+// predefined requests a page of a predefined screen.
+func (c Client) predefined(params *Params, offset, n int) (*result, error) {
+
+	params.scrIds = string(params.Screen)
+	params.count = n
+	params.start = offset
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("/v1/finance/screener/predefined/saved", body, params.Context, &resp)
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+	return resp.result(string(params.Screen))
+}
+
+// custom requests a page of a custom screen.
+func (c Client) custom(params *Params, offset, n int) (*result, error) {
+
+	req := request{
+		Offset:     offset,
+		Size:       n,
+		SortField:  params.SortField,
+		SortType:   "DESC",
+		QuoteType:  params.QuoteType,
+		Query:      params.Query,
+		UserIDType: "guid",
+	}
+	if req.SortField == "" {
+		req.SortField = Ticker
+	}
+	if params.Ascending {
+		req.SortType = "ASC"
+	}
+	if req.QuoteType == "" {
+		req.QuoteType = finance.QuoteTypeEquity
+	}
+
+	resp := response{}
+	err := finance.Post(c.B, "/v1/finance/screener", nil, &req, params.Context, &resp)
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+	return resp.result("screener")
+}
+
+// request is the body of a custom screener request.
+type request struct {
+	Offset     int               `json:"offset"`
+	Size       int               `json:"size"`
+	SortField  Field             `json:"sortField"`
+	SortType   string            `json:"sortType"`
+	QuoteType  finance.QuoteType `json:"quoteType"`
+	Query      *Query            `json:"query"`
+	UserID     string            `json:"userId"`
+	UserIDType string            `json:"userIdType"`
+}
+
+// result is a page of a screen.
+type result struct {
+	ID          string           `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Total       int              `json:"total"`
+	Quotes      []*finance.Quote `json:"quotes"`
+}
+
+// response is a yfin screener response.
+type response struct {
+	Inner struct {
+		Result []*result          `json:"result"`
+		Error  *finance.YfinError `json:"error"`
+	} `json:"finance"`
+}
+
+// result returns the page of a response,
+// or a not found error naming screen.
+func (r *response) result(screen string) (*result, error) {
+	if r.Inner.Error != nil {
+		return nil, r.Inner.Error
+	}
+	if len(r.Inner.Result) == 0 || r.Inner.Result[0] == nil {
+		return nil, finance.CreateNotFoundError(screen)
+	}
+	return r.Inner.Result[0], nil
+}
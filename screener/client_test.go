@@ -0,0 +1,125 @@
+package screener
+
+import (
+	"errors"
+	"testing"
+
+	finance "github.com/piquette/finance-go"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("screeners are only served by the in-process server")
+	}
+}
+
+func symbols(t *testing.T, i *Iter) []string {
+	var list []string
+	for i.Next() {
+		list = append(list, i.Quote().Symbol)
+	}
+	assert.Nil(t, i.Err())
+	return list
+}
+
+func TestPredefined(t *testing.T) {
+	skipMock(t)
+
+	i := Predefined(DayGainers)
+	assert.True(t, i.Next())
+	q := i.Quote()
+	assert.Equal(t, "SMCI", q.Symbol)
+	assert.Equal(t, 10.84, q.RegularMarketChangePercent)
+	assert.Equal(t, "Day Gainers", i.Meta().Title)
+	assert.Equal(t, string(DayGainers), i.Meta().ID)
+	assert.Equal(t, 3, i.Meta().Total)
+	assert.Equal(t, []string{"AMD", "NVDA"}, symbols(t, i))
+}
+
+func TestPredefinedPages(t *testing.T) {
+	skipMock(t)
+
+	i := ListP(&Params{Screen: MostActives, PageSize: 2})
+	assert.Equal(t, []string{"TSLA", "AMD", "NVDA", "AAPL", "F"}, symbols(t, i))
+	assert.Equal(t, 5, i.Count())
+
+	// Pages are only requested as they are needed.
+	i = ListP(&Params{Screen: MostActives, PageSize: 2})
+	assert.True(t, i.Next())
+	assert.Equal(t, 2, i.Count())
+}
+
+func TestLimit(t *testing.T) {
+	skipMock(t)
+
+	i := ListP(&Params{Screen: MostActives, PageSize: 2, Limit: 3})
+	assert.Equal(t, []string{"TSLA", "AMD", "NVDA"}, symbols(t, i))
+	assert.Equal(t, 5, i.Meta().Total)
+}
+
+func TestBadScreen(t *testing.T) {
+	skipMock(t)
+
+	i := Predefined("no_such_screen")
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrNotFound))
+}
+
+func TestCustom(t *testing.T) {
+	skipMock(t)
+
+	i := Custom(And(
+		GT(MarketCap, 100e9),
+		EQ(Sector, "Technology"),
+		EQ(Region, "us"),
+	))
+	// Quotes are sorted by descending ticker by default.
+	assert.Equal(t, []string{"NVDA", "AMD", "AAPL"}, symbols(t, i))
+	assert.Equal(t, 3, i.Meta().Total)
+}
+
+func TestCustomSorted(t *testing.T) {
+	skipMock(t)
+
+	i := ListP(&Params{
+		Query:     And(Between(PERatio, 10, 100), In(Region, "us", "de")),
+		SortField: MarketCap,
+		PageSize:  2,
+	})
+	assert.Equal(t, []string{"AAPL", "NVDA", "TSLA", "SAP", "SMCI"}, symbols(t, i))
+
+	i = ListP(&Params{
+		Query:     Or(LT(PERatio, 10), GTE(PercentChange, 10)),
+		SortField: PercentChange,
+		Ascending: true,
+	})
+	assert.Equal(t, []string{"F", "SMCI"}, symbols(t, i))
+}
+
+func TestCustomQuoteType(t *testing.T) {
+	skipMock(t)
+
+	i := ListP(&Params{
+		Query:     GT(Price, 0),
+		QuoteType: finance.QuoteTypeMutualFund,
+	})
+	assert.Equal(t, []string{"INPSX"}, symbols(t, i))
+}
+
+func TestBadParams(t *testing.T) {
+	for _, p := range []*Params{
+		nil,
+		{},
+		{Screen: DayGainers, Query: GT(Price, 0)},
+		{Screen: DayGainers, PageSize: MaxPageSize + 1},
+		{Screen: DayGainers, Limit: -1},
+		{Query: And()},
+		{Query: &Query{Operator: "gt", Operands: []interface{}{"price", 1}}},
+	} {
+		i := ListP(p)
+		assert.False(t, i.Next())
+		assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+	}
+}
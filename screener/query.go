@@ -0,0 +1,144 @@
+package screener
+
+import "encoding/json"
+
+// Field is a quote field a screen can filter or sort on.
+type Field string
+
+const (
+	// Ticker is the symbol of a quote.
+	Ticker Field = "ticker"
+	// MarketCap is the intraday market capitalization.
+	MarketCap Field = "intradaymarketcap"
+	// Price is the intraday price.
+	Price Field = "intradayprice"
+	// PercentChange is the price change of the day, in percent.
+	PercentChange Field = "percentchange"
+	// Volume is the volume of the day.
+	Volume Field = "dayvolume"
+	// AverageVolume is the average daily volume of the last 3 months.
+	AverageVolume Field = "avgdailyvol3m"
+	// PERatio is the trailing twelve months price to earnings ratio.
+	PERatio Field = "peratio.lasttwelvemonths"
+	// PEGRatio is the five year price/earnings to growth ratio.
+	PEGRatio Field = "pegratio_5y"
+	// EPSGrowth is the trailing twelve months earnings per share growth.
+	EPSGrowth Field = "epsgrowth.lasttwelvemonths"
+	// DividendYield is the forward dividend yield, in percent.
+	DividendYield Field = "forward_dividend_yield"
+	// Sector is the sector of a company, such as "Technology".
+	Sector Field = "sector"
+	// Industry is the industry of a company, such as "Semiconductors".
+	Industry Field = "industry"
+	// Region is the lowercase country code of a listing, such as "us".
+	Region Field = "region"
+	// Exchange is the exchange of a listing, such as "NMS".
+	Exchange Field = "exchange"
+)
+
+// Query is a criterion of a custom screen. Comparisons
+// test a field against values, and And and Or combine them.
+type Query struct {
+	Operator string
+	Operands []interface{}
+}
+
+// MarshalJSON encodes q as yahoo expects it.
+func (q *Query) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Operator string        `json:"operator"`
+		Operands []interface{} `json:"operands"`
+	}{q.Operator, q.Operands})
+}
+
+// And matches quotes matching every query.
+func And(queries ...*Query) *Query {
+	return combine("and", queries)
+}
+
+// Or matches quotes matching any query.
+func Or(queries ...*Query) *Query {
+	return combine("or", queries)
+}
+
+// EQ matches quotes whose field equals value,
+// which is a string or a number.
+func EQ(field Field, value interface{}) *Query {
+	return &Query{Operator: "eq", Operands: []interface{}{field, value}}
+}
+
+// GT matches quotes whose field is greater than value.
+func GT(field Field, value float64) *Query {
+	return &Query{Operator: "gt", Operands: []interface{}{field, value}}
+}
+
+// GTE matches quotes whose field is greater than or equal to value.
+func GTE(field Field, value float64) *Query {
+	return &Query{Operator: "gte", Operands: []interface{}{field, value}}
+}
+
+// LT matches quotes whose field is less than value.
+func LT(field Field, value float64) *Query {
+	return &Query{Operator: "lt", Operands: []interface{}{field, value}}
+}
+
+// LTE matches quotes whose field is less than or equal to value.
+func LTE(field Field, value float64) *Query {
+	return &Query{Operator: "lte", Operands: []interface{}{field, value}}
+}
+
+// Between matches quotes whose field is between low and high, both included.
+func Between(field Field, low, high float64) *Query {
+	return &Query{Operator: "btwn", Operands: []interface{}{field, low, high}}
+}
+
+// In matches quotes whose field equals any of values.
+func In(field Field, values ...string) *Query {
+	queries := make([]*Query, len(values))
+	for i, v := range values {
+		queries[i] = EQ(field, v)
+	}
+	return Or(queries...)
+}
+
+func combine(operator string, queries []*Query) *Query {
+	q := &Query{Operator: operator}
+	for _, o := range queries {
+		if o != nil {
+			q.Operands = append(q.Operands, o)
+		}
+	}
+	return q
+}
+
+// valid reports whether q can be sent: every
+// combination has operands and every comparison
+// a field and the values its operator needs.
+func (q *Query) valid() bool {
+	if q == nil {
+		return false
+	}
+	switch q.Operator {
+	case "and", "or":
+		if len(q.Operands) == 0 {
+			return false
+		}
+		for _, o := range q.Operands {
+			sub, ok := o.(*Query)
+			if !ok || !sub.valid() {
+				return false
+			}
+		}
+		return true
+	case "eq", "gt", "gte", "lt", "lte":
+		return len(q.Operands) == 2 && field(q.Operands[0])
+	case "btwn":
+		return len(q.Operands) == 3 && field(q.Operands[0])
+	}
+	return false
+}
+
+func field(o interface{}) bool {
+	f, ok := o.(Field)
+	return ok && len(f) > 0
+}
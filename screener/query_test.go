@@ -0,0 +1,32 @@
+package screener
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryJSON(t *testing.T) {
+	q := And(GT(MarketCap, 2e9), In(Sector, "Technology", "Healthcare"), Between(PERatio, 0, 20))
+	data, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"operator":"and","operands":[
+		{"operator":"gt","operands":["intradaymarketcap",2000000000]},
+		{"operator":"or","operands":[
+			{"operator":"eq","operands":["sector","Technology"]},
+			{"operator":"eq","operands":["sector","Healthcare"]}
+		]},
+		{"operator":"btwn","operands":["peratio.lasttwelvemonths",0,20]}
+	]}`, string(data))
+	assert.True(t, q.valid())
+}
+
+func TestQueryValid(t *testing.T) {
+	assert.False(t, (*Query)(nil).valid())
+	assert.False(t, Or().valid())
+	assert.False(t, And(EQ("", 1)).valid())
+	assert.False(t, (&Query{Operator: "btwn", Operands: []interface{}{Price, 1}}).valid())
+	assert.False(t, (&Query{Operator: "like", Operands: []interface{}{Sector, "Tech"}}).valid())
+	assert.True(t, And(nil, LTE(Volume, 1e6)).valid())
+}
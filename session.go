@@ -0,0 +1,235 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Session holds the credentials attached to every
+// request made against the yahoo backend.
+type Session struct {
+	// Crumb is sent as the crumb query parameter.
+	Crumb string
+	// Cookies is sent as the Cookie header.
+	Cookies string
+	// Expiry is when the session must be renewed.
+	// A zero Expiry never expires.
+	Expiry time.Time
+}
+
+// expired reports whether the session must be renewed at now.
+func (s *Session) expired(now time.Time) bool {
+	return !s.Expiry.IsZero() && s.Expiry.Before(now)
+}
+
+// SessionProvider obtains new yahoo sessions. The yahoo backend asks
+// for a session before its first call, when the current one expires and
+// when the api rejects it. Tests may supply a fake provider through
+// Config.Sessions or SetSessionProvider.
+type SessionProvider interface {
+	NewSession(ctx context.Context, client *http.Client) (*Session, error)
+}
+
+// SessionProviderFunc adapts a function to the SessionProvider interface.
+type SessionProviderFunc func(ctx context.Context, client *http.Client) (*Session, error)
+
+// NewSession calls f(ctx, client).
+func (f SessionProviderFunc) NewSession(ctx context.Context, client *http.Client) (*Session, error) {
+	return f(ctx, client)
+}
+
+// yahooSessions is the default SessionProvider, fetching cookies
+// from the yahoo login page and a crumb from the api.
+type yahooSessions struct {
+	cookieURL string
+	crumbURL  string
+}
+
+// NewSessionProvider returns a SessionProvider that fetches cookies from
+// cookieURL and then the matching crumb from crumbURL, the way the yahoo
+// backend does by default. It is useful to run against a test server.
+func NewSessionProvider(cookieURL, crumbURL string) SessionProvider {
+	return &yahooSessions{cookieURL: cookieURL, crumbURL: crumbURL}
+}
+
+// NewSession implements SessionProvider.
+func (p *yahooSessions) NewSession(ctx context.Context, client *http.Client) (*Session, error) {
+	cookies, expiry, err := fetchCookies(ctx, client, p.cookieURL)
+	if err != nil {
+		return nil, err
+	}
+
+	crumb, err := fetchCrumb(ctx, client, p.crumbURL, cookies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{Crumb: crumb, Cookies: cookies, Expiry: expiry}, nil
+}
+
+// sessionTimeout bounds a session request, which
+// outlives the call that started it.
+const sessionTimeout = 30 * time.Second
+
+// sessionRefresh is a session request in flight,
+// shared by every caller that needs a new session.
+type sessionRefresh struct {
+	done    chan struct{}
+	session *Session
+	err     error
+}
+
+// session returns a valid session, requesting a new one from the provider
+// if there is none, if it has expired or if it is the rejected one.
+// Concurrent callers share a single request to the provider, made on a
+// context of its own so that no caller cancels it for the others.
+// Each caller waits for it only as long as its own ctx allows.
+func (s *yahooConfiguration) session(ctx context.Context, rejected *Session) (*Session, error) {
+	s.mu.Lock()
+	if cur := s.current; cur != nil && cur != rejected && !cur.expired(time.Now()) {
+		s.mu.Unlock()
+		return cur, nil
+	}
+
+	r := s.refresh
+	if r == nil {
+		r = &sessionRefresh{done: make(chan struct{})}
+		s.refresh = r
+		go s.fetchSession(r)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.session, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetchSession makes the session request r and publishes its outcome.
+func (s *yahooConfiguration) fetchSession(r *sessionRefresh) {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
+	defer cancel()
+
+	r.session, r.err = s.sessions.NewSession(ctx, s.HTTPClient)
+	if r.err == nil && r.session == nil {
+		r.err = errors.New("session provider returned no session")
+	}
+
+	s.mu.Lock()
+	if r.err == nil {
+		s.current = r.session
+	}
+	s.refresh = nil
+	s.mu.Unlock()
+	close(r.done)
+}
+
+// isSessionRejected reports whether err means the
+// api refused the crumb or cookies of a request.
+func isSessionRejected(err error) bool {
+	var herr *HTTPError
+	if errors.As(err, &herr) && (herr.StatusCode == http.StatusUnauthorized || herr.StatusCode == http.StatusForbidden) {
+		return true
+	}
+	var yerr *YfinError
+	return errors.As(err, &yerr) && strings.Contains(yerr.Description, "Invalid Crumb")
+}
+
+// fetchCookies obtains session cookies from the yahoo login page
+// and returns them along with their expiry.
+func fetchCookies(ctx context.Context, client *http.Client, cookieURL string) (string, time.Time, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", cookieURL, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	request.Header = http.Header{
+		"Accept":                   {"*/*"},
+		"Accept-Encoding":          {"gzip, deflate, br"},
+		"Accept-Language":          {"en-US,en;q=0.5"},
+		"Connection":               {"keep-alive"},
+		"Host":                     {"login.yahoo.com"},
+		"Sec-Fetch-Dest":           {"document"},
+		"Sec-Fetch-Mode":           {"navigate"},
+		"Sec-Fetch-Site":           {"none"},
+		"Sec-Fetch-User":           {"?1"},
+		"TE":                       {"trailers"},
+		"Update-Insecure-Requests": {"1"},
+		"User-Agent":               {userAgent},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer response.Body.Close()
+
+	var result string
+	// create a variable expiry that is ten years in the future
+	var expiry = time.Now().AddDate(10, 0, 0)
+
+	for _, cookie := range response.Cookies() {
+
+		if cookie.MaxAge <= 0 {
+			continue
+		}
+
+		cookieExpiry := time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+
+		if cookie.Name != "AS" {
+			result += cookie.Name + "=" + cookie.Value + "; "
+			// set expiry to the latest cookie expiry if smaller than the current expiry
+			if cookie.Expires.Before(cookieExpiry) {
+				expiry = cookieExpiry
+			}
+		}
+	}
+	result = strings.TrimSuffix(result, "; ")
+	return result, expiry, nil
+}
+
+// fetchCrumb obtains the crumb matching the given session cookies.
+func fetchCrumb(ctx context.Context, client *http.Client, crumbURL string, cookies string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", crumbURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	request.Header = http.Header{
+		"Accept":          {"*/*"},
+		"Accept-Encoding": {"gzip, deflate, br"},
+		"Accept-Language": {"en-US,en;q=0.5"},
+		"Connection":      {"keep-alive"},
+		"Content-Type":    {"text/plain"},
+		"Cookie":          {cookies},
+		"Host":            {"query1.finance.yahoo.com"},
+		"Sec-Fetch-Dest":  {"empty"},
+		"Sec-Fetch-Mode":  {"cors"},
+		"Sec-Fetch-Site":  {"same-site"},
+		"TE":              {"trailers"},
+		"User-Agent":      {userAgent},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", createHTTPError(response.StatusCode, request.URL, body)
+	}
+
+	return string(body[:]), nil
+}
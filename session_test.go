@@ -0,0 +1,206 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSessions hands out numbered sessions.
+type fakeSessions struct {
+	calls  int32
+	delay  time.Duration
+	expiry time.Time
+}
+
+func (f *fakeSessions) NewSession(ctx context.Context, client *http.Client) (*Session, error) {
+	n := atomic.AddInt32(&f.calls, 1)
+	time.Sleep(f.delay)
+	return &Session{
+		Crumb:   fmt.Sprintf("crumb%d", n),
+		Cookies: fmt.Sprintf("B=cookie%d", n),
+		Expiry:  f.expiry,
+	}, nil
+}
+
+// crumbServer accepts requests carrying the crumb `valid`
+// and rejects every other one with `status` and `body`.
+func crumbServer(valid string, status int, body string) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Query().Get("crumb") != valid || r.Header.Get("Cookie") != "B=cookie"+valid[len(valid)-1:] {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	return srv, &hits
+}
+
+func newTestYahoo(url string, sessions SessionProvider) Backend {
+	return New(&Config{URL: url, Sessions: sessions, Limiter: NewRateLimiter(0, 0)}).YFin
+}
+
+func TestSessionSingleFlight(t *testing.T) {
+	srv, hits := crumbServer("crumb1", http.StatusUnauthorized, "")
+	defer srv.Close()
+	sessions := &fakeSessions{delay: 50 * time.Millisecond}
+	b := newTestYahoo(srv.URL, sessions)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, b.Call("/", nil, nil, nil))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&sessions.calls))
+	assert.Equal(t, int32(20), atomic.LoadInt32(hits))
+}
+
+func TestSessionRefreshOnUnauthorized(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		srv, hits := crumbServer("crumb2", status, "")
+		sessions := &fakeSessions{}
+		b := newTestYahoo(srv.URL, sessions)
+
+		var v struct{ OK bool }
+		assert.Nil(t, b.Call("/", nil, nil, &v))
+		assert.True(t, v.OK)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&sessions.calls))
+		assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+
+		// The new session is kept for later calls.
+		assert.Nil(t, b.Call("/", nil, nil, nil))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&sessions.calls))
+		srv.Close()
+	}
+}
+
+func TestSessionRefreshOnPost(t *testing.T) {
+	srv, hits := crumbServer("crumb2", http.StatusUnauthorized, "")
+	defer srv.Close()
+	sessions := &fakeSessions{}
+	b := newTestYahoo(srv.URL, sessions)
+
+	var v struct{ OK bool }
+	assert.Nil(t, Post(b, "/", nil, map[string]int{"size": 25}, nil, &v))
+	assert.True(t, v.OK)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&sessions.calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
+
+func TestSessionRefreshOnInvalidCrumb(t *testing.T) {
+	srv, _ := crumbServer("crumb2", http.StatusBadRequest,
+		`{"finance":{"result":null,"error":{"code":"Bad Request","description":"Invalid Crumb"}}}`)
+	defer srv.Close()
+	sessions := &fakeSessions{}
+	b := newTestYahoo(srv.URL, sessions)
+
+	assert.Nil(t, b.Call("/", nil, nil, nil))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&sessions.calls))
+}
+
+func TestSessionRetriedOnce(t *testing.T) {
+	srv, hits := crumbServer("crumb9", http.StatusUnauthorized, "")
+	defer srv.Close()
+	sessions := &fakeSessions{}
+	b := newTestYahoo(srv.URL, sessions)
+
+	err := b.Call("/", nil, nil, nil)
+
+	var herr *HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusUnauthorized, herr.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&sessions.calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
+
+func TestSessionExpiry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	sessions := &fakeSessions{expiry: time.Now().Add(-time.Minute)}
+	b := newTestYahoo(srv.URL, sessions)
+
+	assert.Nil(t, b.Call("/", nil, nil, nil))
+	assert.Nil(t, b.Call("/", nil, nil, nil))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&sessions.calls))
+}
+
+func TestSessionProviderError(t *testing.T) {
+	srv, hits := crumbServer("crumb1", http.StatusUnauthorized, "")
+	defer srv.Close()
+	failure := errors.New("login unavailable")
+	b := newTestYahoo(srv.URL, SessionProviderFunc(func(ctx context.Context, client *http.Client) (*Session, error) {
+		return nil, failure
+	}))
+
+	err := b.Call("/", nil, nil, nil)
+
+	assert.True(t, errors.Is(err, failure))
+	assert.True(t, errors.Is(err, ErrRemote))
+	assert.Equal(t, int32(0), atomic.LoadInt32(hits))
+}
+
+func TestSessionCancelledLeader(t *testing.T) {
+	srv, _ := crumbServer("crumb1", http.StatusUnauthorized, "")
+	defer srv.Close()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	b := newTestYahoo(srv.URL, SessionProviderFunc(func(ctx context.Context, client *http.Client) (*Session, error) {
+		close(started)
+		select {
+		case <-release:
+			return &Session{Crumb: "crumb1", Cookies: "B=cookie1"}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}))
+
+	// The first caller starts the session request, then gives up.
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() { leader <- b.Call("/", nil, &ctx, nil) }()
+	<-started
+	follower := make(chan error)
+	go func() { follower <- b.Call("/", nil, nil, nil) }()
+
+	cancel()
+	assert.True(t, errors.Is(<-leader, context.Canceled))
+
+	// The request goes on for the callers still waiting.
+	close(release)
+	assert.Nil(t, <-follower)
+}
+
+func TestSessionLeavesFormUntouched(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+	}))
+	defer srv.Close()
+	b := newTestYahoo(srv.URL, &fakeSessions{})
+
+	body := &form.Values{}
+	body.Set("symbols", "AAPL")
+	assert.Nil(t, b.Call("/", body, nil, nil))
+	assert.Nil(t, b.Call("/", body, nil, nil))
+	assert.Nil(t, b.Call("/", nil, nil, nil))
+
+	assert.Equal(t, "symbols=AAPL", body.Encode())
+	assert.Equal(t, []string{"symbols=AAPL&crumb=crumb1", "symbols=AAPL&crumb=crumb1", "crumb=crumb1"}, queries)
+}
@@ -0,0 +1,175 @@
+package finance
+
+// Summary holds the quoteSummary modules of a symbol.
+// Modules that were not requested, or that yahoo
+// has no data for, are nil.
+type Summary struct {
+	Symbol               string         `json:"symbol"`
+	AssetProfile         *Profile       `json:"assetProfile,omitempty"`
+	SummaryProfile       *Profile       `json:"summaryProfile,omitempty"`
+	SummaryDetail        *SummaryDetail `json:"summaryDetail,omitempty"`
+	DefaultKeyStatistics *KeyStatistics `json:"defaultKeyStatistics,omitempty"`
+	FinancialData        *FinancialData `json:"financialData,omitempty"`
+}
+
+// Profile is the company profile of the assetProfile
+// and summaryProfile modules. The governance risk
+// scores are only part of assetProfile.
+type Profile struct {
+	Address1            string     `json:"address1"`
+	Address2            string     `json:"address2"`
+	City                string     `json:"city"`
+	State               string     `json:"state"`
+	Zip                 string     `json:"zip"`
+	Country             string     `json:"country"`
+	Phone               string     `json:"phone"`
+	Fax                 string     `json:"fax"`
+	Website             string     `json:"website"`
+	IRWebsite           string     `json:"irWebsite"`
+	Industry            string     `json:"industry"`
+	IndustryKey         string     `json:"industryKey"`
+	Sector              string     `json:"sector"`
+	SectorKey           string     `json:"sectorKey"`
+	LongBusinessSummary string     `json:"longBusinessSummary"`
+	FullTimeEmployees   int        `json:"fullTimeEmployees"`
+	CompanyOfficers     []*Officer `json:"companyOfficers"`
+	AuditRisk           int        `json:"auditRisk"`
+	BoardRisk           int        `json:"boardRisk"`
+	CompensationRisk    int        `json:"compensationRisk"`
+	ShareholderRights   int        `json:"shareHolderRightsRisk"`
+	OverallRisk         int        `json:"overallRisk"`
+	GovernanceDate      Date       `json:"governanceEpochDate"`
+	CompensationDate    Date       `json:"compensationAsOfEpochDate"`
+}
+
+// Officer is a company officer.
+type Officer struct {
+	Name             string `json:"name"`
+	Title            string `json:"title"`
+	Age              int    `json:"age"`
+	YearBorn         int    `json:"yearBorn"`
+	FiscalYear       int    `json:"fiscalYear"`
+	TotalPay         Value  `json:"totalPay"`
+	ExercisedValue   Value  `json:"exercisedValue"`
+	UnexercisedValue Value  `json:"unexercisedValue"`
+}
+
+// SummaryDetail is the summaryDetail module,
+// the trading summary of a symbol.
+type SummaryDetail struct {
+	PreviousClose                Value  `json:"previousClose"`
+	Open                         Value  `json:"open"`
+	DayLow                       Value  `json:"dayLow"`
+	DayHigh                      Value  `json:"dayHigh"`
+	Bid                          Value  `json:"bid"`
+	Ask                          Value  `json:"ask"`
+	BidSize                      Value  `json:"bidSize"`
+	AskSize                      Value  `json:"askSize"`
+	Volume                       Value  `json:"volume"`
+	AverageVolume                Value  `json:"averageVolume"`
+	AverageVolume10Days          Value  `json:"averageVolume10days"`
+	MarketCap                    Value  `json:"marketCap"`
+	Beta                         Value  `json:"beta"`
+	TrailingPE                   Value  `json:"trailingPE"`
+	ForwardPE                    Value  `json:"forwardPE"`
+	PriceToSalesTrailing12Months Value  `json:"priceToSalesTrailing12Months"`
+	FiftyTwoWeekLow              Value  `json:"fiftyTwoWeekLow"`
+	FiftyTwoWeekHigh             Value  `json:"fiftyTwoWeekHigh"`
+	FiftyDayAverage              Value  `json:"fiftyDayAverage"`
+	TwoHundredDayAverage         Value  `json:"twoHundredDayAverage"`
+	DividendRate                 Value  `json:"dividendRate"`
+	DividendYield                Value  `json:"dividendYield"`
+	ExDividendDate               Date   `json:"exDividendDate"`
+	PayoutRatio                  Value  `json:"payoutRatio"`
+	FiveYearAvgDividendYield     Value  `json:"fiveYearAvgDividendYield"`
+	TrailingAnnualDividendRate   Value  `json:"trailingAnnualDividendRate"`
+	TrailingAnnualDividendYield  Value  `json:"trailingAnnualDividendYield"`
+	Yield                        Value  `json:"yield"`
+	TotalAssets                  Value  `json:"totalAssets"`
+	NavPrice                     Value  `json:"navPrice"`
+	YTDReturn                    Value  `json:"ytdReturn"`
+	CirculatingSupply            Value  `json:"circulatingSupply"`
+	MaxSupply                    Value  `json:"maxSupply"`
+	Volume24Hr                   Value  `json:"volume24Hr"`
+	Currency                     string `json:"currency"`
+	FromCurrency                 string `json:"fromCurrency"`
+	ToCurrency                   string `json:"toCurrency"`
+	LastMarket                   string `json:"lastMarket"`
+	Tradeable                    bool   `json:"tradeable"`
+}
+
+// KeyStatistics is the defaultKeyStatistics module,
+// the valuation and share statistics of a symbol.
+type KeyStatistics struct {
+	EnterpriseValue          Value  `json:"enterpriseValue"`
+	EnterpriseToRevenue      Value  `json:"enterpriseToRevenue"`
+	EnterpriseToEbitda       Value  `json:"enterpriseToEbitda"`
+	ForwardPE                Value  `json:"forwardPE"`
+	PegRatio                 Value  `json:"pegRatio"`
+	PriceToBook              Value  `json:"priceToBook"`
+	BookValue                Value  `json:"bookValue"`
+	ProfitMargins            Value  `json:"profitMargins"`
+	Beta                     Value  `json:"beta"`
+	FloatShares              Value  `json:"floatShares"`
+	SharesOutstanding        Value  `json:"sharesOutstanding"`
+	ImpliedSharesOutstanding Value  `json:"impliedSharesOutstanding"`
+	SharesShort              Value  `json:"sharesShort"`
+	SharesShortPriorMonth    Value  `json:"sharesShortPriorMonth"`
+	SharesShortPriorDate     Date   `json:"sharesShortPreviousMonthDate"`
+	ShortInterestDate        Date   `json:"dateShortInterest"`
+	SharesPercentSharesOut   Value  `json:"sharesPercentSharesOut"`
+	ShortRatio               Value  `json:"shortRatio"`
+	ShortPercentOfFloat      Value  `json:"shortPercentOfFloat"`
+	HeldPercentInsiders      Value  `json:"heldPercentInsiders"`
+	HeldPercentInstitutions  Value  `json:"heldPercentInstitutions"`
+	TrailingEps              Value  `json:"trailingEps"`
+	ForwardEps               Value  `json:"forwardEps"`
+	EarningsQuarterlyGrowth  Value  `json:"earningsQuarterlyGrowth"`
+	NetIncomeToCommon        Value  `json:"netIncomeToCommon"`
+	FiftyTwoWeekChange       Value  `json:"52WeekChange"`
+	SandP52WeekChange        Value  `json:"SandP52WeekChange"`
+	LastFiscalYearEnd        Date   `json:"lastFiscalYearEnd"`
+	NextFiscalYearEnd        Date   `json:"nextFiscalYearEnd"`
+	MostRecentQuarter        Date   `json:"mostRecentQuarter"`
+	LastSplitFactor          string `json:"lastSplitFactor"`
+	LastSplitDate            Date   `json:"lastSplitDate"`
+	LastDividendValue        Value  `json:"lastDividendValue"`
+	LastDividendDate         Date   `json:"lastDividendDate"`
+	Category                 string `json:"category"`
+	FundFamily               string `json:"fundFamily"`
+	LegalType                string `json:"legalType"`
+}
+
+// FinancialData is the financialData module, the current
+// financial condition and analyst targets of a company.
+type FinancialData struct {
+	CurrentPrice            Value  `json:"currentPrice"`
+	TargetHighPrice         Value  `json:"targetHighPrice"`
+	TargetLowPrice          Value  `json:"targetLowPrice"`
+	TargetMeanPrice         Value  `json:"targetMeanPrice"`
+	TargetMedianPrice       Value  `json:"targetMedianPrice"`
+	RecommendationMean      Value  `json:"recommendationMean"`
+	RecommendationKey       string `json:"recommendationKey"`
+	NumberOfAnalystOpinions Value  `json:"numberOfAnalystOpinions"`
+	TotalCash               Value  `json:"totalCash"`
+	TotalCashPerShare       Value  `json:"totalCashPerShare"`
+	TotalDebt               Value  `json:"totalDebt"`
+	TotalRevenue            Value  `json:"totalRevenue"`
+	RevenuePerShare         Value  `json:"revenuePerShare"`
+	Ebitda                  Value  `json:"ebitda"`
+	GrossProfits            Value  `json:"grossProfits"`
+	FreeCashflow            Value  `json:"freeCashflow"`
+	OperatingCashflow       Value  `json:"operatingCashflow"`
+	QuickRatio              Value  `json:"quickRatio"`
+	CurrentRatio            Value  `json:"currentRatio"`
+	DebtToEquity            Value  `json:"debtToEquity"`
+	ReturnOnAssets          Value  `json:"returnOnAssets"`
+	ReturnOnEquity          Value  `json:"returnOnEquity"`
+	EarningsGrowth          Value  `json:"earningsGrowth"`
+	RevenueGrowth           Value  `json:"revenueGrowth"`
+	GrossMargins            Value  `json:"grossMargins"`
+	EbitdaMargins           Value  `json:"ebitdaMargins"`
+	OperatingMargins        Value  `json:"operatingMargins"`
+	ProfitMargins           Value  `json:"profitMargins"`
+	FinancialCurrency       string `json:"financialCurrency"`
+}
@@ -0,0 +1,160 @@
+// Package summary fetches the quoteSummary modules of a symbol,
+// such as its company profile and key statistics.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+)
+
+// Module is a quoteSummary module.
+type Module string
+
+const (
+	// AssetProfile is the company profile, officers
+	// and governance risk scores.
+	AssetProfile Module = "assetProfile"
+	// SummaryProfile is the company profile.
+	SummaryProfile Module = "summaryProfile"
+	// SummaryDetail is the trading summary.
+	SummaryDetail Module = "summaryDetail"
+	// DefaultKeyStatistics is the valuation and share statistics.
+	DefaultKeyStatistics Module = "defaultKeyStatistics"
+	// FinancialData is the financial condition and analyst targets.
+	FinancialData Module = "financialData"
+	// EarningsHistory is the reported earnings of recent quarters.
+	EarningsHistory Module = "earningsHistory"
+	// EarningsTrend is the earnings and revenue estimates by period.
+	EarningsTrend Module = "earningsTrend"
+	// RecommendationTrend is the analyst recommendations by month.
+	RecommendationTrend Module = "recommendationTrend"
+	// UpgradeDowngradeHistory is the history of analyst rating changes.
+	UpgradeDowngradeHistory Module = "upgradeDowngradeHistory"
+	// MajorHoldersBreakdown is the share of insider and institutional ownership.
+	MajorHoldersBreakdown Module = "majorHoldersBreakdown"
+	// InstitutionOwnership is the top institutional holders.
+	InstitutionOwnership Module = "institutionOwnership"
+	// FundOwnership is the top mutual fund holders.
+	FundOwnership Module = "fundOwnership"
+	// InsiderHolders is the insider roster.
+	InsiderHolders Module = "insiderHolders"
+	// InsiderTransactions is the recent insider transactions.
+	InsiderTransactions Module = "insiderTransactions"
+	// QuoteType is the names and type of a symbol.
+	QuoteType Module = "quoteType"
+	// FundProfile is the family, category and fees of a fund.
+	FundProfile Module = "fundProfile"
+	// TopHoldings is the portfolio of a fund.
+	TopHoldings Module = "topHoldings"
+	// FundPerformance is the returns of a fund.
+	FundPerformance Module = "fundPerformance"
+	// CalendarEvents is the upcoming earnings and dividend dates.
+	CalendarEvents Module = "calendarEvents"
+)
+
+// DefaultModules are the modules requested
+// when Params.Modules is empty.
+var DefaultModules = []Module{
+	AssetProfile,
+	SummaryDetail,
+	DefaultKeyStatistics,
+	FinancialData,
+}
+
+// Client is used to invoke quoteSummary APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and summary information.
+type Params struct {
+	finance.Params `form:"-"`
+	// Symbol is the symbol summarized.
+	Symbol string `form:"-"`
+	// Modules are the modules requested.
+	Modules []Module `form:"-"`
+	modules string   `form:"modules"`
+}
+
+// Get returns the default modules of a symbol.
+func Get(symbol string) (*finance.Summary, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns the summary modules
+// requested by params.
+func GetP(params *Params) (*finance.Summary, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns the summary modules
+// requested by params.
+func (c Client) GetP(params *Params) (*finance.Summary, error) {
+	s := &finance.Summary{}
+	if err := c.Decode(params, s); err != nil {
+		return nil, err
+	}
+	s.Symbol = params.Symbol
+	return s, nil
+}
+
+// Decode requests the modules of params and decodes
+// them into v, which is usually a pointer to a struct
+// with a field tagged by the name of each module.
+func (c Client) Decode(params *Params, v interface{}) error {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	modules := params.Modules
+	if len(modules) == 0 {
+		modules = DefaultModules
+	}
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = string(m)
+	}
+	params.modules = strings.Join(names, ",")
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("/v10/finance/quoteSummary/"+params.Symbol, body, params.Context, &resp)
+	if err != nil {
+		return finance.CreateRemoteError(err)
+	}
+	if resp.Inner.Error != nil {
+		return resp.Inner.Error
+	}
+	if len(resp.Inner.Result) == 0 {
+		return finance.CreateNotFoundError(params.Symbol)
+	}
+
+	if err := json.Unmarshal(resp.Inner.Result[0], v); err != nil {
+		return finance.CreateDecodeError(err, resp.Inner.Result[0])
+	}
+	return nil
+}
+
+// response is a yfin quoteSummary response.
+type response struct {
+	Inner struct {
+		Result []json.RawMessage  `json:"result"`
+		Error  *finance.YfinError `json:"error"`
+	} `json:"quoteSummary"`
+}
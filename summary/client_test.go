@@ -0,0 +1,118 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("summary modules are only served by the in-process server")
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	skipMock(t)
+
+	s, err := Get(tests.TestEquitySymbol)
+	assert.Nil(t, err)
+	assert.Equal(t, tests.TestEquitySymbol, s.Symbol)
+	assert.Nil(t, s.SummaryProfile)
+
+	p := s.AssetProfile
+	assert.Equal(t, "Technology", p.Sector)
+	assert.Equal(t, "Consumer Electronics", p.Industry)
+	assert.Equal(t, 161000, p.FullTimeEmployees)
+	assert.Len(t, p.CompanyOfficers, 2)
+	assert.Equal(t, "CEO & Director", p.CompanyOfficers[0].Title)
+	assert.Equal(t, int64(16239562), p.CompanyOfficers[0].TotalPay.Int())
+	assert.False(t, p.CompanyOfficers[1].ExercisedValue.Valid)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), p.GovernanceDate.Time)
+
+	d := s.SummaryDetail
+	assert.Equal(t, 1.29, d.Beta.Raw)
+	assert.Equal(t, "2.85T", d.MarketCap.Fmt)
+	assert.Equal(t, "2023-11-10", d.ExDividendDate.Fmt)
+	assert.False(t, d.NavPrice.Valid)
+
+	k := s.DefaultKeyStatistics
+	assert.Equal(t, int64(15535332634), k.FloatShares.Int())
+	assert.Equal(t, 0.0069, k.ShortPercentOfFloat.Raw)
+	assert.Equal(t, "2023-12-15", k.ShortInterestDate.Fmt)
+	assert.Equal(t, "4:1", k.LastSplitFactor)
+
+	f := s.FinancialData
+	assert.Equal(t, "buy", f.RecommendationKey)
+	assert.Equal(t, 0.44131002, f.GrossMargins.Raw)
+	assert.Equal(t, "-0.72%", f.RevenueGrowth.Fmt)
+}
+
+func TestGetModules(t *testing.T) {
+	skipMock(t)
+
+	s, err := GetP(&Params{Symbol: tests.TestEquitySymbol, Modules: []Module{SummaryProfile}})
+	assert.Nil(t, err)
+	assert.NotNil(t, s.SummaryProfile)
+	assert.Equal(t, "Cupertino", s.SummaryProfile.City)
+	assert.Nil(t, s.AssetProfile)
+	assert.Nil(t, s.SummaryDetail)
+	assert.Nil(t, s.DefaultKeyStatistics)
+	assert.Nil(t, s.FinancialData)
+}
+
+func TestDecodeModules(t *testing.T) {
+	skipMock(t)
+
+	var v struct {
+		Detail struct {
+			Beta finance.Value `json:"beta"`
+		} `json:"summaryDetail"`
+	}
+	err := Client{B: finance.GetBackend(finance.YFinBackend)}.Decode(&Params{
+		Symbol:  tests.TestEquitySymbol,
+		Modules: []Module{SummaryDetail},
+	}, &v)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.29", v.Detail.Beta.Fmt)
+}
+
+func TestBadSymbolSummary(t *testing.T) {
+	skipMock(t)
+
+	_, err := Get("BADSYMBOL")
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+func TestNilParamsSummary(t *testing.T) {
+	_, err := GetP(nil)
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+
+	_, err = Get("")
+	assert.True(t, errors.Is(err, finance.ErrArgument))
+}
+
+// rawBackend answers every call with body.
+type rawBackend struct {
+	body string
+}
+
+func (b rawBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	return json.Unmarshal([]byte(b.body), v)
+}
+
+func TestDecodeErrorBody(t *testing.T) {
+	c := Client{B: rawBackend{`{"quoteSummary": {"result": [{"summaryProfile": []}], "error": null}}`}}
+
+	_, err := c.GetP(&Params{Symbol: "AAPL", Modules: []Module{SummaryProfile}})
+	var derr *finance.DecodeError
+	assert.True(t, errors.As(err, &derr))
+	assert.Equal(t, `{"summaryProfile": []}`, derr.Body)
+}
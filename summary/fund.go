@@ -0,0 +1,191 @@
+package summary
+
+import (
+	"strconv"
+
+	finance "github.com/piquette/finance-go"
+)
+
+// FundModules are the modules a fund profile is built from.
+var FundModules = []Module{
+	QuoteType,
+	FundProfile,
+	TopHoldings,
+	FundPerformance,
+	DefaultKeyStatistics,
+}
+
+// GetFundProfile returns the profile of an etf or mutual fund.
+// It requests FundModules, whatever the modules of params.
+func (c Client) GetFundProfile(params *Params) (*finance.FundProfile, error) {
+	if params == nil {
+		return nil, finance.CreateArgumentError()
+	}
+	p := *params
+	p.Modules = FundModules
+
+	r := fundResult{}
+	if err := c.Decode(&p, &r); err != nil {
+		return nil, err
+	}
+	if r.FundProfile == nil {
+		return nil, finance.CreateNotFoundError(params.Symbol)
+	}
+	return r.profile(params.Symbol), nil
+}
+
+// fundResult is the quoteSummary result of a fund profile.
+type fundResult struct {
+	QuoteType *struct {
+		LongName  string `json:"longName"`
+		ShortName string `json:"shortName"`
+	} `json:"quoteType"`
+	FundProfile *struct {
+		Family    string `json:"family"`
+		Category  string `json:"categoryName"`
+		LegalType string `json:"legalType"`
+		Fees      struct {
+			ExpenseRatio   finance.Value `json:"annualReportExpenseRatio"`
+			Turnover       finance.Value `json:"annualHoldingsTurnover"`
+			TotalNetAssets finance.Value `json:"totalNetAssets"`
+		} `json:"feesExpensesInvestment"`
+	} `json:"fundProfile"`
+	TopHoldings *struct {
+		Stock       finance.Value `json:"stockPosition"`
+		Bond        finance.Value `json:"bondPosition"`
+		Cash        finance.Value `json:"cashPosition"`
+		Preferred   finance.Value `json:"preferredPosition"`
+		Convertible finance.Value `json:"convertiblePosition"`
+		Other       finance.Value `json:"otherPosition"`
+		Holdings    []*struct {
+			Symbol  string        `json:"symbol"`
+			Name    string        `json:"holdingName"`
+			Percent finance.Value `json:"holdingPercent"`
+		} `json:"holdings"`
+		// Weightings are lists of single entry objects,
+		// as in [{"technology": {"raw": 0.28}}, ...].
+		SectorWeightings []map[string]finance.Value `json:"sectorWeightings"`
+		BondRatings      []map[string]finance.Value `json:"bondRatings"`
+	} `json:"topHoldings"`
+	FundPerformance *struct {
+		TrailingReturns struct {
+			AsOfDate   finance.Date  `json:"asOfDate"`
+			YTD        finance.Value `json:"ytd"`
+			OneMonth   finance.Value `json:"oneMonth"`
+			ThreeMonth finance.Value `json:"threeMonth"`
+			OneYear    finance.Value `json:"oneYear"`
+			ThreeYear  finance.Value `json:"threeYear"`
+			FiveYear   finance.Value `json:"fiveYear"`
+			TenYear    finance.Value `json:"tenYear"`
+		} `json:"trailingReturns"`
+		AnnualTotalReturns struct {
+			Returns []*struct {
+				Year  string        `json:"year"`
+				Value finance.Value `json:"annualValue"`
+			} `json:"returns"`
+		} `json:"annualTotalReturns"`
+	} `json:"fundPerformance"`
+	DefaultKeyStatistics *struct {
+		FundFamily    string        `json:"fundFamily"`
+		InceptionDate finance.Date  `json:"fundInceptionDate"`
+		TotalAssets   finance.Value `json:"totalAssets"`
+		Yield         finance.Value `json:"yield"`
+		ExpenseRatio  finance.Value `json:"annualReportExpenseRatio"`
+		Turnover      finance.Value `json:"annualHoldingsTurnover"`
+	} `json:"defaultKeyStatistics"`
+}
+
+// profile converts r into the profile of symbol.
+func (r *fundResult) profile(symbol string) *finance.FundProfile {
+	f := &finance.FundProfile{
+		Symbol:           symbol,
+		Family:           r.FundProfile.Family,
+		Category:         r.FundProfile.Category,
+		LegalType:        r.FundProfile.LegalType,
+		ExpenseRatio:     r.FundProfile.Fees.ExpenseRatio,
+		Turnover:         r.FundProfile.Fees.Turnover,
+		TotalAssets:      r.FundProfile.Fees.TotalNetAssets,
+		SectorWeightings: map[string]finance.Value{},
+		BondRatings:      map[string]finance.Value{},
+	}
+
+	if q := r.QuoteType; q != nil {
+		f.Name = q.LongName
+		if f.Name == "" {
+			f.Name = q.ShortName
+		}
+	}
+
+	if k := r.DefaultKeyStatistics; k != nil {
+		f.InceptionDate = k.InceptionDate.Time
+		f.Yield = k.Yield
+		if f.Family == "" {
+			f.Family = k.FundFamily
+		}
+		if !f.ExpenseRatio.Valid {
+			f.ExpenseRatio = k.ExpenseRatio
+		}
+		if !f.Turnover.Valid {
+			f.Turnover = k.Turnover
+		}
+		if !f.TotalAssets.Valid {
+			f.TotalAssets = k.TotalAssets
+		}
+	}
+
+	if h := r.TopHoldings; h != nil {
+		f.Allocation = finance.FundAllocation{
+			Stock:       h.Stock,
+			Bond:        h.Bond,
+			Cash:        h.Cash,
+			Preferred:   h.Preferred,
+			Convertible: h.Convertible,
+			Other:       h.Other,
+		}
+		for _, holding := range h.Holdings {
+			if holding == nil {
+				continue
+			}
+			f.Holdings = append(f.Holdings, &finance.FundHolding{
+				Symbol: holding.Symbol,
+				Name:   holding.Name,
+				Weight: holding.Percent,
+			})
+		}
+		merge(f.SectorWeightings, h.SectorWeightings)
+		merge(f.BondRatings, h.BondRatings)
+	}
+
+	if p := r.FundPerformance; p != nil {
+		t := p.TrailingReturns
+		f.Performance = finance.FundPerformance{
+			AsOf:       t.AsOfDate.Time,
+			YTD:        t.YTD,
+			OneMonth:   t.OneMonth,
+			ThreeMonth: t.ThreeMonth,
+			OneYear:    t.OneYear,
+			ThreeYear:  t.ThreeYear,
+			FiveYear:   t.FiveYear,
+			TenYear:    t.TenYear,
+			Annual:     map[int]finance.Value{},
+		}
+		for _, a := range p.AnnualTotalReturns.Returns {
+			if a == nil {
+				continue
+			}
+			if year, err := strconv.Atoi(a.Year); err == nil && a.Value.Valid {
+				f.Performance.Annual[year] = a.Value
+			}
+		}
+	}
+	return f
+}
+
+// merge adds the entries of a list of weightings to m.
+func merge(m map[string]finance.Value, weightings []map[string]finance.Value) {
+	for _, w := range weightings {
+		for k, v := range w {
+			m[k] = v
+		}
+	}
+}
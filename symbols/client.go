@@ -1 +1,163 @@
+// Package symbols looks up symbols by free text, as the yahoo
+// finance search box does to suggest them while typing.
 package symbols
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	"github.com/piquette/finance-go/iter"
+)
+
+const (
+	// DefaultCount is the number of results
+	// requested when Params.Count is zero.
+	DefaultCount = 10
+	// FilteredCount is the number of results requested when
+	// Params.QuoteTypes or Params.Exchanges is set, unless
+	// Count is larger, so that Count results survive the filters.
+	FilteredCount = 100
+)
+
+// Client is used to invoke search APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and search information.
+type Params struct {
+	finance.Params `form:"-"`
+	// Query is the text searched for, such as a
+	// symbol prefix or part of a company name.
+	Query string `form:"q"`
+	// Count is the number of results requested.
+	Count int `form:"-"`
+	// Fuzzy also matches misspelled queries.
+	Fuzzy bool `form:"enableFuzzyQuery"`
+	// QuoteTypes, if set, keeps only the
+	// results of these quote types.
+	// Filters apply to the first FilteredCount results.
+	QuoteTypes []finance.QuoteType `form:"-"`
+	// Exchanges, if set, keeps only the results
+	// listed on these exchanges, such as "NMS".
+	Exchanges []string `form:"-"`
+	count     int      `form:"quotesCount"`
+}
+
+// Iter is an iterator for a list of search results.
+// The embedded iter.Of carries methods with it;
+// see its documentation for details.
+type Iter struct {
+	*iter.Of[*finance.SearchResult]
+}
+
+// Result returns the most recent search result
+// visited by a call to Next.
+func (i *Iter) Result() *finance.SearchResult {
+	return i.Current()
+}
+
+// Meta returns the meta data associated with the search.
+func (i *Iter) Meta() *finance.SearchMeta {
+	meta, _ := i.Of.Meta().(*finance.SearchMeta)
+	return meta
+}
+
+// Search returns the symbols matching query.
+func Search(query string) *Iter {
+	return SearchP(&Params{Query: query})
+}
+
+// SearchP returns a search result iterator
+// and requires a params struct as an argument.
+func SearchP(params *Params) *Iter {
+	return getC().SearchP(params)
+}
+
+// SearchP returns a search result iterator.
+func (c Client) SearchP(params *Params) *Iter {
+
+	if params == nil || len(params.Query) == 0 || params.Count < 0 {
+		return &Iter{iter.NewOfE[*finance.SearchResult](finance.CreateArgumentError())}
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	limit := params.Count
+	if limit == 0 {
+		limit = DefaultCount
+	}
+	params.count = limit
+	if (len(params.QuoteTypes) > 0 || len(params.Exchanges) > 0) && params.count < FilteredCount {
+		params.count = FilteredCount
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	return &Iter{iter.New(body, func(b *form.Values) (interface{}, []*finance.SearchResult, error) {
+
+		resp := response{}
+		err := c.B.Call("/v1/finance/search", b, params.Context, &resp)
+		if err != nil {
+			return nil, nil, finance.CreateRemoteError(err)
+		}
+		if resp.Finance != nil && resp.Finance.Error != nil {
+			return nil, nil, resp.Finance.Error
+		}
+
+		meta := &finance.SearchMeta{
+			Query:  params.Query,
+			Total:  resp.Count,
+			Counts: make(map[finance.QuoteType]int),
+		}
+		results := make([]*finance.SearchResult, 0, len(resp.Quotes))
+		for _, r := range resp.Quotes {
+			if r == nil || len(r.Symbol) == 0 {
+				continue
+			}
+			meta.Counts[r.QuoteType]++
+			if len(results) < limit && keep(params, r) {
+				results = append(results, r)
+			}
+		}
+		return meta, results, nil
+	})}
+}
+
+// keep reports whether r passes the filters of params.
+func keep(params *Params, r *finance.SearchResult) bool {
+	if len(params.QuoteTypes) > 0 && !contains(params.QuoteTypes, r.QuoteType) {
+		return false
+	}
+	if len(params.Exchanges) > 0 && !contains(params.Exchanges, r.Exchange) {
+		return false
+	}
+	return true
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, l := range list {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
+
+// response is a yfin search response.
+type response struct {
+	Count   int                     `json:"count"`
+	Quotes  []*finance.SearchResult `json:"quotes"`
+	Finance *struct {
+		Error *finance.YfinError `json:"error"`
+	} `json:"finance"`
+}
@@ -1 +1,99 @@
 package symbols
+
+import (
+	"errors"
+	"testing"
+
+	finance "github.com/piquette/finance-go"
+	tests "github.com/piquette/finance-go/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipMock(t *testing.T) {
+	if tests.DefaultServer == nil {
+		t.Skip("search results are only served by the in-process server")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	skipMock(t)
+
+	i := Search("apple")
+	var got []string
+	for i.Next() {
+		got = append(got, i.Result().Symbol)
+	}
+	assert.Nil(t, i.Err())
+	assert.Equal(t, []string{"AAPL", "APC.DE", "AAPL.MX", "APLE"}, got)
+	assert.Equal(t, 4, i.Count())
+
+	meta := i.Meta()
+	assert.Equal(t, "apple", meta.Query)
+	assert.Equal(t, 4, meta.Total)
+	assert.Equal(t, 4, meta.Counts[finance.QuoteTypeEquity])
+}
+
+func TestSearchResult(t *testing.T) {
+	skipMock(t)
+
+	i := Search(tests.TestEquitySymbol)
+	assert.True(t, i.Next())
+	r := i.Result()
+	assert.Equal(t, tests.TestEquitySymbol, r.Symbol)
+	assert.Equal(t, "Apple Inc.", r.LongName)
+	assert.Equal(t, "NMS", r.Exchange)
+	assert.Equal(t, "NASDAQ", r.ExchangeDisplay)
+	assert.Equal(t, finance.QuoteTypeEquity, r.QuoteType)
+	assert.Equal(t, "Technology", r.Sector)
+	assert.NotZero(t, r.Score)
+}
+
+func TestSearchFilters(t *testing.T) {
+	skipMock(t)
+
+	i := SearchP(&Params{Query: "aapl", QuoteTypes: []finance.QuoteType{finance.QuoteTypeETF}})
+	assert.True(t, i.Next())
+	assert.Equal(t, "AAPD", i.Result().Symbol)
+	assert.False(t, i.Next())
+	assert.Nil(t, i.Err())
+	assert.Equal(t, 3, i.Meta().Total)
+	assert.Equal(t, 2, i.Meta().Counts[finance.QuoteTypeEquity])
+	assert.Equal(t, 1, i.Meta().Counts[finance.QuoteTypeETF])
+
+	i = SearchP(&Params{Query: "apple", Exchanges: []string{"NMS", "NYQ"}})
+	var got []string
+	for i.Next() {
+		got = append(got, i.Result().Symbol)
+	}
+	assert.Equal(t, []string{"AAPL", "APLE"}, got)
+
+	// Matches beyond the first Count results are found.
+	i = SearchP(&Params{Query: "apple", Count: 1, Exchanges: []string{"NYQ"}})
+	assert.True(t, i.Next())
+	assert.Equal(t, "APLE", i.Result().Symbol)
+	assert.False(t, i.Next())
+
+	i = SearchP(&Params{Query: "apple", Count: 1})
+	assert.True(t, i.Next())
+	assert.False(t, i.Next())
+	assert.Equal(t, 4, i.Meta().Total)
+}
+
+func TestSearchNoResults(t *testing.T) {
+	skipMock(t)
+
+	i := Search("zzz")
+	assert.False(t, i.Next())
+	assert.Nil(t, i.Err())
+	assert.Equal(t, 0, i.Meta().Total)
+}
+
+func TestNilParamsSearch(t *testing.T) {
+	i := SearchP(nil)
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+
+	i = Search("")
+	assert.False(t, i.Next())
+	assert.True(t, errors.Is(i.Err(), finance.ErrArgument))
+}
@@ -0,0 +1,821 @@
+package testing
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+)
+
+//go:embed testdata/*.json
+var fixtures embed.FS
+
+const (
+	// cookiePath sets the session cookie, standing in for the yahoo login page.
+	cookiePath = "/login"
+	// crumbPath returns the crumb matching the session cookie.
+	crumbPath = "/v1/test/getcrumb"
+	// sessionCookie is the name of the session cookie.
+	sessionCookie = "B"
+)
+
+// Fault describes a failure injected into the api
+// responses of a Server. The crumb and cookie
+// endpoints are never affected.
+type Fault struct {
+	// Latency delays each response.
+	Latency time.Duration
+	// StatusCode, if set, replaces each response
+	// with an empty error response of that status.
+	StatusCode int
+	// Malformed truncates each response body.
+	Malformed bool
+	// NullArrays replaces result arrays with null, and every
+	// other chart indicator value with null.
+	NullArrays bool
+	// Count is the number of responses affected,
+	// or 0 to affect responses until the fault is cleared.
+	Count int
+}
+
+// Server is an in-process fake of the yahoo finance api serving the
+// quote, chart, options, search, quoteSummary, fundamentals timeseries,
+// calendar events and screener endpoints from fixture files, along with
+// the cookie and crumb endpoints needed to establish a session.
+// Requests without the current crumb are rejected with 401 Invalid Crumb.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	state   finance.MarketState
+	crumb   int
+	fault   *Fault
+	hits    int
+	quotes  map[string]json.RawMessage
+	charts  map[string]*chartResult
+	options map[string]json.RawMessage
+	search  []json.RawMessage
+	summary map[string]map[string]json.RawMessage
+	series  map[string]map[string][]*seriesPoint
+	events  map[string][]map[string]interface{}
+	screens screenFixture
+}
+
+// NewServer starts a Server in regular market state.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		state:   finance.MarketStateRegular,
+		crumb:   1,
+		quotes:  make(map[string]json.RawMessage),
+		charts:  make(map[string]*chartResult),
+		options: make(map[string]json.RawMessage),
+	}
+	s.load()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(cookiePath, s.serveCookie)
+	mux.HandleFunc(crumbPath, s.serveCrumb)
+	mux.Handle("/v7/finance/quote", s.api(s.serveQuote))
+	mux.Handle("/v8/finance/chart/", s.api(s.serveChart))
+	mux.Handle("/v7/finance/options/", s.api(s.serveOptions))
+	mux.Handle("/v1/finance/search", s.api(s.serveSearch))
+	mux.Handle("/v10/finance/quoteSummary/", s.api(s.serveSummary))
+	mux.Handle("/ws/fundamentals-timeseries/v1/finance/timeseries/", s.api(s.serveTimeseries))
+	mux.Handle("/ws/screeners/v1/finance/calendar-events", s.api(s.serveCalendarEvents))
+	mux.Handle("/v1/finance/screener/predefined/saved", s.api(s.servePredefinedScreen))
+	mux.Handle("/v1/finance/screener", s.api(s.serveScreener))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Backend returns a yahoo backend bound to the server.
+// It establishes its session through the server's cookie and
+// crumb endpoints, and neither retries nor throttles requests.
+func (s *Server) Backend() finance.Backend {
+	return finance.New(&finance.Config{
+		HTTPClient: s.Client(),
+		URL:        s.URL,
+		Sessions:   finance.NewSessionProvider(s.URL+cookiePath, s.URL+crumbPath),
+		Retry:      &finance.RetryPolicy{MaxAttempts: 1},
+		Limiter:    finance.NewRateLimiter(0, 0),
+	}).YFin
+}
+
+// SetMarket sets the market state reported in quotes.
+func (s *Server) SetMarket(state finance.MarketState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+}
+
+// SetFault injects f into the following api responses.
+func (s *Server) SetFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fault = &f
+}
+
+// ClearFault removes any injected fault.
+func (s *Server) ClearFault() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fault = nil
+}
+
+// InvalidateCrumb rotates the crumb, so that the
+// next api request of every client is rejected.
+func (s *Server) InvalidateCrumb() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.crumb++
+}
+
+// Hits returns the number of api requests served,
+// not counting the cookie and crumb endpoints.
+func (s *Server) Hits() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits
+}
+
+// load reads the fixture files.
+func (s *Server) load() {
+	var quotes []json.RawMessage
+	mustDecode("testdata/quotes.json", &quotes)
+	for _, q := range quotes {
+		var head struct {
+			Symbol string `json:"symbol"`
+		}
+		json.Unmarshal(q, &head)
+		s.quotes[head.Symbol] = q
+	}
+	mustDecode("testdata/charts.json", &s.charts)
+	mustDecode("testdata/options.json", &s.options)
+	mustDecode("testdata/search.json", &s.search)
+	mustDecode("testdata/summary.json", &s.summary)
+	mustDecode("testdata/timeseries.json", &s.series)
+	mustDecode("testdata/calendar_events.json", &s.events)
+	mustDecode("testdata/screener.json", &s.screens)
+}
+
+// mustDecode decodes an embedded fixture file into v.
+func mustDecode(name string, v interface{}) {
+	data, err := fixtures.ReadFile(name)
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("testing: bad fixture %s: %v", name, err))
+	}
+}
+
+// currentCrumb returns the crumb matching the current session.
+// The caller must hold s.mu.
+func (s *Server) currentCrumb() string {
+	return "crumb" + strconv.Itoa(s.crumb)
+}
+
+func (s *Server) serveCookie(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	value := strconv.Itoa(s.crumb)
+	s.mu.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: value, MaxAge: 3600})
+}
+
+func (s *Server) serveCrumb(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := r.Cookie(sessionCookie)
+	if err != nil || c.Value != strconv.Itoa(s.crumb) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Write([]byte(s.currentCrumb()))
+}
+
+// api wraps an api endpoint with crumb validation and fault injection.
+// Handlers return the response status and a body to be encoded.
+func (s *Server) api(h func(r *http.Request, fault *Fault) (int, interface{})) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.hits++
+		crumb := s.currentCrumb()
+		var fault *Fault
+		if s.fault != nil {
+			f := *s.fault
+			fault = &f
+			if s.fault.Count > 0 {
+				s.fault.Count--
+				if s.fault.Count == 0 {
+					s.fault = nil
+				}
+			}
+		}
+		s.mu.Unlock()
+
+		if r.URL.Query().Get("crumb") != crumb {
+			writeJSON(w, http.StatusUnauthorized, errorBody("finance", "Unauthorized", "Invalid Crumb"), nil)
+			return
+		}
+
+		if fault != nil && fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault != nil && fault.StatusCode > 0 {
+			w.WriteHeader(fault.StatusCode)
+			return
+		}
+
+		status, body := h(r, fault)
+		writeJSON(w, status, body, fault)
+	})
+}
+
+// writeJSON encodes body, truncating it for malformed faults.
+func writeJSON(w http.ResponseWriter, status int, body interface{}, fault *Fault) {
+	data, _ := json.Marshal(body)
+	if fault != nil && fault.Malformed {
+		data = data[:len(data)/2]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// errorBody builds a yahoo error response under the given envelope key.
+func errorBody(envelope, code, description string) interface{} {
+	return map[string]interface{}{
+		envelope: map[string]interface{}{
+			"result": nil,
+			"error":  &finance.YfinError{Code: code, Description: description},
+		},
+	}
+}
+
+func (s *Server) serveQuote(r *http.Request, fault *Fault) (int, interface{}) {
+	s.mu.Lock()
+	state := s.state
+	s.mu.Unlock()
+
+	result := []map[string]interface{}{}
+	for _, symbol := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+		raw, ok := s.quotes[symbol]
+		if !ok {
+			continue
+		}
+		var q map[string]interface{}
+		json.Unmarshal(raw, &q)
+		q["marketState"] = marketState(state)
+		result = append(result, q)
+	}
+
+	var body interface{} = result
+	if fault != nil && fault.NullArrays {
+		body = nil
+	}
+	return http.StatusOK, map[string]interface{}{
+		"quoteResponse": map[string]interface{}{"result": body, "error": nil},
+	}
+}
+
+// marketState maps a state to the one reported by finance-mock,
+// which only knows about the pre, regular and post sessions.
+func marketState(state finance.MarketState) finance.MarketState {
+	switch state {
+	case finance.MarketStatePre, finance.MarketStatePrePre:
+		return finance.MarketStatePre
+	case finance.MarketStatePost, finance.MarketStatePostPost:
+		return finance.MarketStatePost
+	}
+	return finance.MarketStateRegular
+}
+
+// chartResult is a chart fixture.
+type chartResult struct {
+	Meta       json.RawMessage `json:"meta"`
+	Timestamp  []int           `json:"timestamp"`
+	Indicators struct {
+		Quote    []chartQuote    `json:"quote"`
+		Adjclose []chartAdjclose `json:"adjclose,omitempty"`
+	} `json:"indicators"`
+}
+
+// chartQuote holds the bar values of a chart fixture.
+type chartQuote struct {
+	Open   []interface{} `json:"open"`
+	High   []interface{} `json:"high"`
+	Low    []interface{} `json:"low"`
+	Close  []interface{} `json:"close"`
+	Volume []interface{} `json:"volume"`
+}
+
+// chartAdjclose holds the adjusted closes of a chart fixture.
+type chartAdjclose struct {
+	Adjclose []interface{} `json:"adjclose"`
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+func (s *Server) serveChart(r *http.Request, fault *Fault) (int, interface{}) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/v8/finance/chart/")
+	fixture, ok := s.charts[symbol]
+	if !ok {
+		return http.StatusNotFound, errorBody("chart", "Not Found", "No data found, symbol may be delisted")
+	}
+
+	// Keep the bars of every day touched by [period1, period2].
+	from, to := 0, int(^uint(0)>>1)
+	if p, err := strconv.Atoi(r.URL.Query().Get("period1")); err == nil && p >= 0 {
+		from = p - p%secondsPerDay
+	}
+	if p, err := strconv.Atoi(r.URL.Query().Get("period2")); err == nil && p >= 0 {
+		to = p - p%secondsPerDay + secondsPerDay
+	}
+
+	var c chartResult
+	c.Meta = fixture.Meta
+	c.Indicators.Quote = make([]chartQuote, len(fixture.Indicators.Quote))
+	c.Indicators.Adjclose = make([]chartAdjclose, len(fixture.Indicators.Adjclose))
+
+	nulls := fault != nil && fault.NullArrays
+	pick := func(src []interface{}, i, n int) interface{} {
+		if nulls && n%2 == 1 {
+			return nil
+		}
+		return src[i]
+	}
+	n := 0
+	for i, t := range fixture.Timestamp {
+		if t < from || t >= to {
+			continue
+		}
+		c.Timestamp = append(c.Timestamp, t)
+		for j, q := range fixture.Indicators.Quote {
+			dst := &c.Indicators.Quote[j]
+			dst.Open = append(dst.Open, pick(q.Open, i, n))
+			dst.High = append(dst.High, pick(q.High, i, n))
+			dst.Low = append(dst.Low, pick(q.Low, i, n))
+			dst.Close = append(dst.Close, pick(q.Close, i, n))
+			dst.Volume = append(dst.Volume, pick(q.Volume, i, n))
+		}
+		for j, a := range fixture.Indicators.Adjclose {
+			dst := &c.Indicators.Adjclose[j]
+			dst.Adjclose = append(dst.Adjclose, pick(a.Adjclose, i, n))
+		}
+		n++
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"chart": map[string]interface{}{"result": []interface{}{c}, "error": nil},
+	}
+}
+
+func (s *Server) serveOptions(r *http.Request, fault *Fault) (int, interface{}) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/v7/finance/options/")
+	raw, ok := s.options[symbol]
+	if !ok {
+		return http.StatusOK, map[string]interface{}{
+			"optionChain": map[string]interface{}{"result": []interface{}{}, "error": nil},
+		}
+	}
+
+	var result map[string]interface{}
+	json.Unmarshal(raw, &result)
+
+	chains, _ := result["options"].([]interface{})
+	for _, c := range chains {
+		chain := c.(map[string]interface{})
+		if fault != nil && fault.NullArrays {
+			chain["straddles"] = nil
+			continue
+		}
+		if r.URL.Query().Get("straddle") == "true" {
+			continue
+		}
+		// Unroll straddles into separate call and put lists.
+		var calls, puts []interface{}
+		straddles, _ := chain["straddles"].([]interface{})
+		for _, st := range straddles {
+			st := st.(map[string]interface{})
+			if st["call"] != nil {
+				calls = append(calls, st["call"])
+			}
+			if st["put"] != nil {
+				puts = append(puts, st["put"])
+			}
+		}
+		delete(chain, "straddles")
+		chain["calls"] = calls
+		chain["puts"] = puts
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"optionChain": map[string]interface{}{"result": []interface{}{result}, "error": nil},
+	}
+}
+
+// serveSearch matches the query against the start of each symbol
+// and anywhere in each name, keeping the fixture order.
+func (s *Server) serveSearch(r *http.Request, fault *Fault) (int, interface{}) {
+	query := strings.ToLower(r.URL.Query().Get("q"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("quotesCount"))
+	if err != nil {
+		limit = 6
+	}
+
+	quotes := []json.RawMessage{}
+	count := 0
+	for _, raw := range s.search {
+		var head struct {
+			Symbol    string `json:"symbol"`
+			ShortName string `json:"shortname"`
+			LongName  string `json:"longname"`
+		}
+		json.Unmarshal(raw, &head)
+		if !strings.HasPrefix(strings.ToLower(head.Symbol), query) &&
+			!strings.Contains(strings.ToLower(head.ShortName), query) &&
+			!strings.Contains(strings.ToLower(head.LongName), query) {
+			continue
+		}
+		count++
+		if len(quotes) < limit {
+			quotes = append(quotes, raw)
+		}
+	}
+
+	var body interface{} = quotes
+	if fault != nil && fault.NullArrays {
+		body = nil
+	}
+	return http.StatusOK, map[string]interface{}{
+		"explains": []interface{}{},
+		"count":    count,
+		"quotes":   body,
+		"news":     []interface{}{},
+	}
+}
+
+// serveSummary serves the requested modules a symbol has fixtures for.
+func (s *Server) serveSummary(r *http.Request, fault *Fault) (int, interface{}) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/v10/finance/quoteSummary/")
+	fixture, ok := s.summary[symbol]
+	if !ok {
+		return http.StatusNotFound, errorBody("quoteSummary", "Not Found", "Quote not found for ticker symbol: "+symbol)
+	}
+
+	result := map[string]json.RawMessage{}
+	for _, m := range strings.Split(r.URL.Query().Get("modules"), ",") {
+		if raw, ok := fixture[m]; ok {
+			result[m] = raw
+		}
+	}
+
+	var body interface{} = []interface{}{result}
+	if fault != nil && fault.NullArrays {
+		body = nil
+	}
+	return http.StatusOK, map[string]interface{}{
+		"quoteSummary": map[string]interface{}{"result": body, "error": nil},
+	}
+}
+
+// seriesPoint is a fundamentals timeseries fixture value.
+type seriesPoint struct {
+	AsOfDate      string          `json:"asOfDate"`
+	PeriodType    string          `json:"periodType"`
+	CurrencyCode  string          `json:"currencyCode"`
+	DataID        int             `json:"dataId"`
+	ReportedValue json.RawMessage `json:"reportedValue"`
+}
+
+// serveTimeseries serves a series for each requested type, with the
+// points whose date falls in [period1, period2]. Types without points
+// only carry their meta, as yahoo does.
+func (s *Server) serveTimeseries(r *http.Request, fault *Fault) (int, interface{}) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/ws/fundamentals-timeseries/v1/finance/timeseries/")
+	fixture := s.series[symbol]
+
+	from, _ := strconv.ParseInt(r.URL.Query().Get("period1"), 10, 64)
+	to, err := strconv.ParseInt(r.URL.Query().Get("period2"), 10, 64)
+	if err != nil {
+		to = time.Now().Unix()
+	}
+
+	result := []interface{}{}
+	for _, typ := range strings.Split(r.URL.Query().Get("type"), ",") {
+		series := map[string]interface{}{
+			"meta": map[string]interface{}{"symbol": []string{symbol}, "type": []string{typ}},
+		}
+		var timestamps []int64
+		var points []*seriesPoint
+		for _, p := range fixture[typ] {
+			var t time.Time
+			if p != nil {
+				t, _ = time.Parse("2006-01-02", p.AsOfDate)
+				if t.Unix() < from || t.Unix() > to {
+					continue
+				}
+			}
+			timestamps = append(timestamps, t.Unix())
+			points = append(points, p)
+		}
+		if len(points) > 0 {
+			series["timestamp"] = timestamps
+			series[typ] = points
+		}
+		result = append(result, series)
+	}
+
+	var body interface{} = result
+	if fault != nil && fault.NullArrays {
+		body = nil
+	}
+	return http.StatusOK, map[string]interface{}{
+		"timeseries": map[string]interface{}{"result": body, "error": nil},
+	}
+}
+
+// serveCalendarEvents serves the events of each requested module between
+// startDate and endDate, in unix milliseconds, grouped by day.
+func (s *Server) serveCalendarEvents(r *http.Request, fault *Fault) (int, interface{}) {
+	q := r.URL.Query()
+	from, _ := strconv.ParseInt(q.Get("startDate"), 10, 64)
+	to, _ := strconv.ParseInt(q.Get("endDate"), 10, 64)
+	perDay, err := strconv.Atoi(q.Get("countPerDay"))
+	if err != nil {
+		perDay = 25
+	}
+
+	result := map[string]interface{}{}
+	for _, module := range strings.Split(q.Get("modules"), ",") {
+		records, ok := s.events[module]
+		if !ok {
+			continue
+		}
+		var days []map[string]interface{}
+		byDay := map[int64]map[string]interface{}{}
+		count := 0
+		for _, rec := range records {
+			at, _ := rec["startDateTime"].(float64)
+			if t, ok := rec["eventTime"].(float64); ok {
+				at = t
+			}
+			ms := int64(at)
+			if ms < from || ms > to {
+				continue
+			}
+			day := ms - ms%(secondsPerDay*1000)
+			group, ok := byDay[day]
+			if !ok {
+				group = map[string]interface{}{"timestamp": day, "records": []interface{}{}}
+				byDay[day] = group
+				days = append(days, group)
+			}
+			if recs := group["records"].([]interface{}); len(recs) < perDay {
+				group["records"] = append(recs, rec)
+				count++
+			}
+		}
+		sort.Slice(days, func(i, j int) bool {
+			return days[i]["timestamp"].(int64) < days[j]["timestamp"].(int64)
+		})
+		var body interface{} = days
+		if fault != nil && fault.NullArrays {
+			body = nil
+		}
+		result[module] = map[string]interface{}{"count": count, "resultsByDay": body}
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"finance": map[string]interface{}{"result": []interface{}{result}, "error": nil},
+	}
+}
+
+// screenFixture holds the predefined screens, by id,
+// and the quotes screened by the screener endpoints.
+type screenFixture struct {
+	Screens map[string]struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Symbols     []string `json:"symbols"`
+	} `json:"screens"`
+	Quotes []map[string]interface{} `json:"quotes"`
+}
+
+// screenerFields maps screener fields to the quote fields they read.
+var screenerFields = map[string]string{
+	"ticker":                   "symbol",
+	"intradaymarketcap":        "marketCap",
+	"intradayprice":            "regularMarketPrice",
+	"percentchange":            "regularMarketChangePercent",
+	"dayvolume":                "regularMarketVolume",
+	"avgdailyvol3m":            "averageDailyVolume3Month",
+	"peratio.lasttwelvemonths": "trailingPE",
+	"sector":                   "sector",
+	"industry":                 "industry",
+	"region":                   "region",
+	"exchange":                 "exchange",
+}
+
+// servePredefinedScreen serves a page of the quotes of a predefined
+// screen, from start and at most count quotes long.
+func (s *Server) servePredefinedScreen(r *http.Request, fault *Fault) (int, interface{}) {
+	q := r.URL.Query()
+	id := q.Get("scrIds")
+	screen, ok := s.screens.Screens[id]
+	if !ok {
+		return http.StatusNotFound, errorBody("finance", "Not Found", "No screener found for id: "+id)
+	}
+
+	var quotes []map[string]interface{}
+	for _, symbol := range screen.Symbols {
+		for _, quote := range s.screens.Quotes {
+			if quote["symbol"] == symbol {
+				quotes = append(quotes, quote)
+			}
+		}
+	}
+	start, _ := strconv.Atoi(q.Get("start"))
+	count, _ := strconv.Atoi(q.Get("count"))
+
+	result := screenPage(quotes, start, count, fault)
+	result["id"] = id
+	result["title"] = screen.Title
+	result["description"] = screen.Description
+	return http.StatusOK, map[string]interface{}{
+		"finance": map[string]interface{}{"result": []interface{}{result}, "error": nil},
+	}
+}
+
+// serveScreener serves a page of the quotes
+// matching the query of a POSTed custom screen.
+func (s *Server) serveScreener(r *http.Request, fault *Fault) (int, interface{}) {
+	var req struct {
+		Offset    int             `json:"offset"`
+		Size      int             `json:"size"`
+		SortField string          `json:"sortField"`
+		SortType  string          `json:"sortType"`
+		QuoteType string          `json:"quoteType"`
+		Query     json.RawMessage `json:"query"`
+	}
+	if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+		return http.StatusBadRequest, errorBody("finance", "Bad Request", "Invalid screener request")
+	}
+	var query screenQuery
+	if json.Unmarshal(req.Query, &query) != nil || !query.valid() {
+		return http.StatusBadRequest, errorBody("finance", "Bad Request", "Invalid screener query")
+	}
+	sortField, ok := screenerFields[req.SortField]
+	if !ok {
+		return http.StatusBadRequest, errorBody("finance", "Bad Request", "Invalid sort field: "+req.SortField)
+	}
+
+	var quotes []map[string]interface{}
+	for _, quote := range s.screens.Quotes {
+		if quote["quoteType"] == req.QuoteType && query.match(quote) {
+			quotes = append(quotes, quote)
+		}
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		less := compare(quotes[i][sortField], quotes[j][sortField]) < 0
+		if req.SortType == "DESC" {
+			less = compare(quotes[i][sortField], quotes[j][sortField]) > 0
+		}
+		return less
+	})
+
+	result := screenPage(quotes, req.Offset, req.Size, fault)
+	return http.StatusOK, map[string]interface{}{
+		"finance": map[string]interface{}{"result": []interface{}{result}, "error": nil},
+	}
+}
+
+// screenPage returns the result of a page of quotes.
+func screenPage(quotes []map[string]interface{}, start, count int, fault *Fault) map[string]interface{} {
+	page := []map[string]interface{}{}
+	for i := start; i >= 0 && i < len(quotes) && len(page) < count; i++ {
+		page = append(page, quotes[i])
+	}
+
+	var body interface{} = page
+	if fault != nil && fault.NullArrays {
+		body = nil
+	}
+	return map[string]interface{}{
+		"start":  start,
+		"count":  len(page),
+		"total":  len(quotes),
+		"quotes": body,
+	}
+}
+
+// screenQuery is the query of a custom screen.
+type screenQuery struct {
+	Operator string            `json:"operator"`
+	Operands []json.RawMessage `json:"operands"`
+}
+
+// valid reports whether q and its operands are well formed.
+func (q *screenQuery) valid() bool {
+	switch q.Operator {
+	case "and", "or":
+		if len(q.Operands) == 0 {
+			return false
+		}
+		for _, raw := range q.Operands {
+			var sub screenQuery
+			if json.Unmarshal(raw, &sub) != nil || !sub.valid() {
+				return false
+			}
+		}
+		return true
+	case "eq", "gt", "gte", "lt", "lte", "btwn":
+		n := 2
+		if q.Operator == "btwn" {
+			n = 3
+		}
+		var field string
+		if len(q.Operands) != n || json.Unmarshal(q.Operands[0], &field) != nil {
+			return false
+		}
+		_, ok := screenerFields[field]
+		return ok
+	}
+	return false
+}
+
+// match reports whether quote matches the valid query q.
+func (q *screenQuery) match(quote map[string]interface{}) bool {
+	switch q.Operator {
+	case "and", "or":
+		for _, raw := range q.Operands {
+			var sub screenQuery
+			json.Unmarshal(raw, &sub)
+			if sub.match(quote) != (q.Operator == "and") {
+				return q.Operator == "or"
+			}
+		}
+		return q.Operator == "and"
+	}
+
+	var field string
+	json.Unmarshal(q.Operands[0], &field)
+	v, ok := quote[screenerFields[field]]
+	if !ok {
+		return false
+	}
+	values := make([]interface{}, len(q.Operands)-1)
+	for i, raw := range q.Operands[1:] {
+		json.Unmarshal(raw, &values[i])
+	}
+
+	switch q.Operator {
+	case "eq":
+		return compare(v, values[0]) == 0
+	case "gt":
+		return compare(v, values[0]) > 0
+	case "gte":
+		return compare(v, values[0]) >= 0
+	case "lt":
+		return compare(v, values[0]) < 0
+	case "lte":
+		return compare(v, values[0]) <= 0
+	}
+	return compare(v, values[0]) >= 0 && compare(v, values[1]) <= 0
+}
+
+// compare orders two fixture values of the same
+// kind, numbers or strings. Missing values sort first.
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case float64:
+		b, _ := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(a, b)
+	}
+	if b == nil {
+		return 0
+	}
+	return -1
+}
@@ -0,0 +1,288 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	assert "github.com/stretchr/testify/require"
+)
+
+type quoteResponse struct {
+	Inner struct {
+		Result []*finance.Quote `json:"result"`
+	} `json:"quoteResponse"`
+}
+
+type chartResponse struct {
+	Inner struct {
+		Result []struct {
+			Meta       finance.ChartMeta `json:"meta"`
+			Timestamp  []int             `json:"timestamp"`
+			Indicators struct {
+				Quote []struct {
+					Open []*float64 `json:"open"`
+				} `json:"quote"`
+			} `json:"indicators"`
+		} `json:"result"`
+	} `json:"chart"`
+}
+
+func symbols(s string) *form.Values {
+	body := &form.Values{}
+	body.Set("symbols", s)
+	return body
+}
+
+func TestServerQuote(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	resp := quoteResponse{}
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol+","+TestETFSymbol+",TEST"), nil, &resp))
+	assert.Len(t, resp.Inner.Result, 2)
+	assert.Equal(t, TestEquitySymbol, resp.Inner.Result[0].Symbol)
+	assert.Equal(t, finance.MarketStateRegular, resp.Inner.Result[0].MarketState)
+
+	s.SetMarket(finance.MarketStatePostPost)
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, &resp))
+	assert.Equal(t, finance.MarketStatePost, resp.Inner.Result[0].MarketState)
+}
+
+func TestServerChart(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	resp := chartResponse{}
+	assert.Nil(t, b.Call("/v8/finance/chart/"+TestEquitySymbol, nil, nil, &resp))
+	all := len(resp.Inner.Result[0].Timestamp)
+	assert.True(t, all > 10)
+	assert.Equal(t, TestEquitySymbol, resp.Inner.Result[0].Meta.Symbol)
+
+	day := time.Date(TestYear, TestMonth, TestDay, 9, 30, 0, 0, time.UTC).Unix()
+	body := &form.Values{}
+	body.Set("period1", "1515628800")
+	body.Set("period2", "1515628800")
+	resp = chartResponse{}
+	assert.Nil(t, b.Call("/v8/finance/chart/"+TestEquitySymbol, body, nil, &resp))
+	assert.Len(t, resp.Inner.Result[0].Timestamp, 1)
+	assert.True(t, int64(resp.Inner.Result[0].Timestamp[0])-day < 24*60*60)
+
+	err := b.Call("/v8/finance/chart/BADSYMBOL", nil, nil, nil)
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+func TestServerOptions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	var resp struct {
+		Inner struct {
+			Result []struct {
+				Options []struct {
+					Calls     []*finance.Contract `json:"calls"`
+					Straddles []*finance.Straddle `json:"straddles"`
+				} `json:"options"`
+			} `json:"result"`
+		} `json:"optionChain"`
+	}
+	assert.Nil(t, b.Call("/v7/finance/options/"+TestStraddleSymbol, nil, nil, &resp))
+	assert.NotEmpty(t, resp.Inner.Result[0].Options[0].Calls)
+	assert.Empty(t, resp.Inner.Result[0].Options[0].Straddles)
+
+	body := &form.Values{}
+	body.Set("straddle", "true")
+	assert.Nil(t, b.Call("/v7/finance/options/"+TestStraddleSymbol, body, nil, &resp))
+	assert.NotEmpty(t, resp.Inner.Result[0].Options[0].Straddles)
+}
+
+func TestServerSearch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	var resp struct {
+		Count  int                     `json:"count"`
+		Quotes []*finance.SearchResult `json:"quotes"`
+	}
+	body := &form.Values{}
+	body.Set("q", "apple")
+	body.Set("quotesCount", "2")
+	assert.Nil(t, b.Call("/v1/finance/search", body, nil, &resp))
+	assert.Equal(t, 4, resp.Count)
+	assert.Len(t, resp.Quotes, 2)
+	assert.Equal(t, TestEquitySymbol, resp.Quotes[0].Symbol)
+
+	body.Set("q", "zzz")
+	assert.Nil(t, b.Call("/v1/finance/search", body, nil, &resp))
+	assert.Equal(t, 0, resp.Count)
+	assert.Empty(t, resp.Quotes)
+}
+
+func TestServerSummary(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	var resp struct {
+		Inner struct {
+			Result []map[string]interface{} `json:"result"`
+		} `json:"quoteSummary"`
+	}
+	body := &form.Values{}
+	body.Set("modules", "summaryDetail,financialData,earnings")
+	assert.Nil(t, b.Call("/v10/finance/quoteSummary/"+TestEquitySymbol, body, nil, &resp))
+	assert.Len(t, resp.Inner.Result[0], 2)
+	assert.Contains(t, resp.Inner.Result[0], "summaryDetail")
+
+	err := b.Call("/v10/finance/quoteSummary/TEST", body, nil, &resp)
+	assert.True(t, errors.Is(err, finance.ErrNotFound))
+}
+
+func TestServerTimeseries(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	var resp struct {
+		Inner struct {
+			Result []map[string]interface{} `json:"result"`
+		} `json:"timeseries"`
+	}
+	body := &form.Values{}
+	body.Set("type", "annualTotalRevenue,annualUnknown")
+	body.Set("period1", "1609459200")
+	assert.Nil(t, b.Call("/ws/fundamentals-timeseries/v1/finance/timeseries/"+TestEquitySymbol, body, nil, &resp))
+	assert.Len(t, resp.Inner.Result, 2)
+	assert.Len(t, resp.Inner.Result[0]["annualTotalRevenue"], 3)
+	assert.NotContains(t, resp.Inner.Result[1], "annualUnknown")
+}
+
+func TestServerCalendarEvents(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	var resp struct {
+		Inner struct {
+			Result []map[string]struct {
+				ResultsByDay []struct {
+					Timestamp int64         `json:"timestamp"`
+					Records   []interface{} `json:"records"`
+				} `json:"resultsByDay"`
+			} `json:"result"`
+		} `json:"finance"`
+	}
+	body := &form.Values{}
+	body.Set("modules", "earnings,unknown")
+	body.Set("startDate", "1704067200000")
+	body.Set("endDate", "1706745599999")
+	assert.Nil(t, b.Call("/ws/screeners/v1/finance/calendar-events", body, nil, &resp))
+	assert.Len(t, resp.Inner.Result, 1)
+	assert.NotContains(t, resp.Inner.Result[0], "unknown")
+	days := resp.Inner.Result[0]["earnings"].ResultsByDay
+	assert.Len(t, days, 1)
+	assert.Equal(t, int64(1705017600000), days[0].Timestamp)
+	assert.Len(t, days[0].Records, 2)
+}
+
+func TestServerScreener(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	var resp struct {
+		Inner struct {
+			Result []struct {
+				ID     string                   `json:"id"`
+				Total  int                      `json:"total"`
+				Quotes []map[string]interface{} `json:"quotes"`
+			} `json:"result"`
+		} `json:"finance"`
+	}
+	body := &form.Values{}
+	body.Set("scrIds", "most_actives")
+	body.Set("count", "2")
+	body.Set("start", "4")
+	assert.Nil(t, b.Call("/v1/finance/screener/predefined/saved", body, nil, &resp))
+	assert.Equal(t, "most_actives", resp.Inner.Result[0].ID)
+	assert.Equal(t, 5, resp.Inner.Result[0].Total)
+	assert.Len(t, resp.Inner.Result[0].Quotes, 1)
+
+	query := map[string]interface{}{
+		"offset": 0, "size": 10, "sortField": "ticker", "sortType": "ASC", "quoteType": "EQUITY",
+		"query": map[string]interface{}{"operator": "eq", "operands": []interface{}{"sector", "Consumer Cyclical"}},
+	}
+	assert.Nil(t, finance.Post(b, "/v1/finance/screener", nil, query, nil, &resp))
+	assert.Equal(t, 2, resp.Inner.Result[0].Total)
+	assert.Equal(t, "F", resp.Inner.Result[0].Quotes[0]["symbol"])
+
+	query["query"] = map[string]interface{}{"operator": "eq", "operands": []interface{}{"unknown", 1}}
+	err := finance.Post(b, "/v1/finance/screener", nil, query, nil, &resp)
+	var herr *finance.HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusBadRequest, herr.StatusCode)
+}
+
+func TestServerInvalidCrumb(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, nil))
+	s.InvalidateCrumb()
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, nil))
+	assert.Equal(t, 3, s.Hits())
+
+	// Requests without a crumb at all are rejected.
+	plain := &finance.BackendConfiguration{Type: finance.YFinBackend, URL: s.URL, HTTPClient: s.Client()}
+	err := plain.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, nil)
+	var herr *finance.HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusUnauthorized, herr.StatusCode)
+	assert.Equal(t, "Invalid Crumb", herr.Upstream.Description)
+}
+
+func TestServerFaults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	b := s.Backend()
+
+	s.SetFault(Fault{StatusCode: http.StatusServiceUnavailable, Count: 1})
+	err := b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, nil)
+	var herr *finance.HTTPError
+	assert.True(t, errors.As(err, &herr))
+	assert.Equal(t, http.StatusServiceUnavailable, herr.StatusCode)
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, nil))
+
+	s.SetFault(Fault{Malformed: true})
+	resp := quoteResponse{}
+	err = b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, &resp)
+	assert.True(t, errors.Is(err, finance.ErrDecode))
+
+	s.SetFault(Fault{NullArrays: true})
+	resp = quoteResponse{}
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, &resp))
+	assert.Nil(t, resp.Inner.Result)
+	chart := chartResponse{}
+	assert.Nil(t, b.Call("/v8/finance/chart/"+TestEquitySymbol, nil, nil, &chart))
+	opens := chart.Inner.Result[0].Indicators.Quote[0].Open
+	assert.NotNil(t, opens[0])
+	assert.Nil(t, opens[1])
+
+	s.SetFault(Fault{Latency: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = b.Call("/v7/finance/quote", symbols(TestEquitySymbol), &ctx, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	s.ClearFault()
+	assert.Nil(t, b.Call("/v7/finance/quote", symbols(TestEquitySymbol), nil, nil))
+}
@@ -38,6 +38,13 @@ const (
 	TestYear             = 2018
 )
 
+// DefaultServer is the in-process fake api the test suite runs against,
+// unless FINANCE_MOCK_PORT points it at an external finance-mock instead.
+var DefaultServer *Server
+
+// mockURL is the URL of the external finance-mock, if one is used.
+var mockURL string
+
 func init() {
 	// Enable strict mode on form encoding so that we'll panic if any kind of
 	// malformed param struct is detected
@@ -45,10 +52,13 @@ func init() {
 
 	port := os.Getenv("FINANCE_MOCK_PORT")
 	if port == "" {
-		port = "12111"
+		DefaultServer = NewServer()
+		finance.SetBackend(finance.YFinBackend, DefaultServer.Backend())
+		return
 	}
 
-	resp, err := http.Get("http://" + TestServerAddr + ":" + port)
+	mockURL = "http://" + TestServerAddr + ":" + port
+	resp, err := http.Get(mockURL)
 	if err != nil || resp.StatusCode != http.StatusOK {
 		fmt.Fprintf(os.Stderr, "Couldn't reach finance-mock at `%s:%s`. Is "+
 			"it running? Please see README for setup instructions.\n", TestServerAddr, port)
@@ -65,13 +75,18 @@ func init() {
 
 	finance.SetBackend(finance.YFinBackend, &finance.BackendConfiguration{
 		Type:       finance.YFinBackend,
-		URL:        "http://" + TestServerAddr + ":" + port,
+		URL:        mockURL,
 		HTTPClient: &http.Client{},
 	})
 }
 
 // SetMarket sets the test server to the state/session specified.
 func SetMarket(state finance.MarketState) {
+	if DefaultServer != nil {
+		DefaultServer.SetMarket(state)
+		return
+	}
+
 	// one of regular/post/pre
 	var mktState string
 
@@ -90,11 +105,8 @@ func SetMarket(state finance.MarketState) {
 	form.Add("state", mktState)
 
 	// Post.
-	resp, err := http.PostForm("http://localhost:12111/config/", form)
+	resp, err := http.PostForm(mockURL+"/config/", form)
 	if err != nil || resp.StatusCode != http.StatusOK {
-
-		fmt.Println(resp.Request.Form)
-
 		fmt.Fprintf(os.Stderr, "Couldn't change state of finance-mock. Is "+
 			"it running? Please see README for setup instructions.\n")
 		os.Exit(1)
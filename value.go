@@ -0,0 +1,97 @@
+package finance
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Value is a number reported by yahoo along with its
+// display formats, as in {"raw": 0.153, "fmt": "15.30%"}.
+// Plain numbers decode into a Value too.
+type Value struct {
+	Raw     float64
+	Fmt     string
+	LongFmt string
+	// Valid is false if yahoo reported no value,
+	// which it does with null or an empty object.
+	Valid bool
+	num   json.Number
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	*v = Value{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		v.set(data)
+		return nil
+	}
+
+	var w struct {
+		Raw     json.RawMessage `json:"raw"`
+		Fmt     string          `json:"fmt"`
+		LongFmt string          `json:"longFmt"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	v.Fmt, v.LongFmt = w.Fmt, w.LongFmt
+	v.set(w.Raw)
+	return nil
+}
+
+// set sets the raw value from a JSON number, or a string
+// holding one. Anything else, such as null or "Infinity",
+// leaves v invalid.
+func (v *Value) set(data []byte) {
+	num := json.Number(bytes.Trim(data, `"`))
+	raw, err := num.Float64()
+	if err != nil || math.IsInf(raw, 0) || math.IsNaN(raw) {
+		return
+	}
+	v.Raw, v.Valid, v.num = raw, true, num
+}
+
+// Int returns the raw value as an integer.
+func (v Value) Int() int64 {
+	if i, err := v.num.Int64(); err == nil {
+		return i
+	}
+	return int64(v.Raw)
+}
+
+// Decimal returns the raw value exactly as
+// yahoo wrote it, without a round trip
+// through a float.
+func (v Value) Decimal() decimal.Decimal {
+	if d, err := decimal.NewFromString(string(v.num)); err == nil {
+		return d
+	}
+	return decimal.NewFromFloat(v.Raw)
+}
+
+// Date is a date or timestamp reported by yahoo as unix
+// seconds, either plain or as in {"raw": 1696032000, "fmt": "2023-09-30"}.
+type Date struct {
+	Time time.Time
+	Fmt  string
+	// Valid is false if yahoo reported no date.
+	Valid bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var v Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*d = Date{}
+	if v.Valid {
+		*d = Date{Time: time.Unix(v.Int(), 0).UTC(), Fmt: v.Fmt, Valid: true}
+	}
+	return nil
+}
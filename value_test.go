@@ -0,0 +1,69 @@
+package finance
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue(t *testing.T) {
+	var v struct {
+		Wrapped Value `json:"wrapped"`
+		Plain   Value `json:"plain"`
+		Empty   Value `json:"empty"`
+		Null    Value `json:"null"`
+		NoRaw   Value `json:"noRaw"`
+		Inf     Value `json:"inf"`
+		Big     Value `json:"big"`
+	}
+	err := json.Unmarshal([]byte(`{
+		"wrapped": {"raw": 0.1533, "fmt": "15.33%"},
+		"plain": 161000,
+		"empty": {},
+		"null": null,
+		"noRaw": {"fmt": null},
+		"inf": {"raw": "Infinity", "fmt": "∞"},
+		"big": {"raw": 2853794709504, "fmt": "2.85T", "longFmt": "2,853,794,709,504"}
+	}`), &v)
+	assert.Nil(t, err)
+
+	assert.True(t, v.Wrapped.Valid)
+	assert.Equal(t, 0.1533, v.Wrapped.Raw)
+	assert.Equal(t, "15.33%", v.Wrapped.Fmt)
+	assert.Equal(t, "0.1533", v.Wrapped.Decimal().String())
+
+	assert.True(t, v.Plain.Valid)
+	assert.Equal(t, int64(161000), v.Plain.Int())
+
+	assert.False(t, v.Empty.Valid)
+	assert.False(t, v.Null.Valid)
+	assert.False(t, v.NoRaw.Valid)
+	assert.False(t, v.Inf.Valid)
+	assert.Equal(t, "∞", v.Inf.Fmt)
+
+	assert.Equal(t, int64(2853794709504), v.Big.Int())
+	assert.Equal(t, "2,853,794,709,504", v.Big.LongFmt)
+}
+
+func TestDate(t *testing.T) {
+	var d struct {
+		Wrapped Date `json:"wrapped"`
+		Plain   Date `json:"plain"`
+		Empty   Date `json:"empty"`
+	}
+	err := json.Unmarshal([]byte(`{
+		"wrapped": {"raw": 1696032000, "fmt": "2023-09-30"},
+		"plain": 1704067200,
+		"empty": {}
+	}`), &d)
+	assert.Nil(t, err)
+
+	assert.True(t, d.Wrapped.Valid)
+	assert.Equal(t, time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC), d.Wrapped.Time)
+	assert.Equal(t, "2023-09-30", d.Wrapped.Fmt)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), d.Plain.Time)
+	assert.False(t, d.Empty.Valid)
+	assert.True(t, d.Empty.Time.IsZero())
+}
@@ -3,6 +3,7 @@ package finance
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -24,6 +25,12 @@ func (e *YfinError) Error() string {
 	return string(ret)
 }
 
+// Is reports whether target is ErrRemote,
+// or ErrNotFound for "Not Found" errors.
+func (e *YfinError) Is(target error) bool {
+	return target == ErrRemote || (target == ErrNotFound && e.Code == "Not Found")
+}
+
 type (
 	// QuoteType alias for asset classification.
 	QuoteType string
@@ -247,6 +254,30 @@ type ChartBar struct {
 	AdjClose  decimal.Decimal
 	Volume    int
 	Timestamp int
+	// Valid is false if yahoo reported no prices for the bar,
+	// as happens for halted or illiquid intervals.
+	Valid bool
+}
+
+// Dividend is a dividend paid per share.
+type Dividend struct {
+	Amount decimal.Decimal
+	Date   int
+}
+
+// Split is a stock split of Numerator
+// new shares for every Denominator old ones.
+type Split struct {
+	Numerator   float64
+	Denominator float64
+	Ratio       string
+	Date        int
+}
+
+// CapitalGain is a capital gain distributed per share.
+type CapitalGain struct {
+	Amount decimal.Decimal
+	Date   int
 }
 
 // OHLCHistoric is a historical quotation.
@@ -295,6 +326,12 @@ type ChartMeta struct {
 	ValidRanges     []string `json:"validRanges" csv:"-"`
 }
 
+// Location returns the timezone of the exchange
+// named by ExchangeTimezoneName, or UTC if it is empty.
+func (m ChartMeta) Location() (*time.Location, error) {
+	return time.LoadLocation(m.ExchangeTimezoneName)
+}
+
 // OptionsMeta is meta data associated with an options response.
 type OptionsMeta struct {
 	UnderlyingSymbol   string    `json:"underlyingSymbol" csv:"underlyingSymbol"`
@@ -330,3 +367,38 @@ type Contract struct {
 	ImpliedVolatility float64 `json:"impliedVolatility" csv:"impliedVolatility"`
 	InTheMoney        bool    `json:"inTheMoney" csv:"inTheMoney"`
 }
+
+// SearchResult is a symbol matching a search.
+type SearchResult struct {
+	Symbol          string    `json:"symbol" csv:"symbol"`
+	ShortName       string    `json:"shortname" csv:"shortname"`
+	LongName        string    `json:"longname" csv:"longname"`
+	Exchange        string    `json:"exchange" csv:"exchange"`
+	ExchangeDisplay string    `json:"exchDisp" csv:"exchDisp"`
+	QuoteType       QuoteType `json:"quoteType" csv:"quoteType"`
+	TypeDisplay     string    `json:"typeDisp" csv:"typeDisp"`
+	Score           float64   `json:"score" csv:"score"`
+	Sector          string    `json:"sector" csv:"sector"`
+	Industry        string    `json:"industry" csv:"industry"`
+}
+
+// SearchMeta is meta data associated with a search response.
+type SearchMeta struct {
+	Query string `json:"query" csv:"query"`
+	// Total is the number of results reported by yahoo,
+	// before any filter is applied.
+	Total int `json:"count" csv:"count"`
+	// Counts is the number of results received
+	// of each quote type, before any filter is applied.
+	Counts map[QuoteType]int `json:"-" csv:"-"`
+}
+
+// ScreenMeta is meta data associated with a screener response.
+type ScreenMeta struct {
+	// ID is the id of a predefined screen.
+	ID          string `json:"id" csv:"id"`
+	Title       string `json:"title" csv:"title"`
+	Description string `json:"description" csv:"description"`
+	// Total is the number of quotes passing the screen.
+	Total int `json:"total" csv:"total"`
+}